@@ -53,7 +53,7 @@ func Bcp(probability float64) (data.AnalysisFunc, error) {
 				cpCount++
 			}
 		}
-		fmt.Printf("  %d change points in %s\n", cpCount, tr.Test())
+		fmt.Printf("  %d change points in %s\n", cpCount, tr.ID())
 		return ret, nil
 	}, nil
 }
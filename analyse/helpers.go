@@ -44,7 +44,7 @@ func vectoriseAll(r data.TestResult) [][]float64 {
 }
 
 func incorrectTestResultState(commit string, tr data.TestResult) {
-	panic(fmt.Sprintf("Incorrect test result state: %s @ %s", tr.Test(), commit))
+	panic(fmt.Sprintf("Incorrect test result state: %s @ %s", tr.ID(), commit))
 }
 
 func f64SliceToString(s []float64) string {
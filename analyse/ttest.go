@@ -63,7 +63,7 @@ func Ttest(sig float64, paired bool) (data.AnalysisFunc, error) {
 				cpCount++
 			}
 		}
-		fmt.Printf("  %d change points in %s\n", cpCount, tr.Test())
+		fmt.Printf("  %d change points in %s\n", cpCount, tr.ID())
 		return cps, nil
 	}, nil
 }
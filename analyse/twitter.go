@@ -52,7 +52,7 @@ func Twitter(minMean int) (data.AnalysisFunc, error) {
 			cps.Add(newCp)
 			cpCount++
 		}
-		fmt.Printf("  %d change points in %s\n", cpCount, tr.Test())
+		fmt.Printf("  %d change points in %s\n", cpCount, tr.ID())
 		return cps, nil
 	}, nil
 }
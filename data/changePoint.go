@@ -20,17 +20,28 @@ func NewChangePoint(commit string, test TestResult) (ChangePoint, error) {
 	if test == nil {
 		return nil, fmt.Errorf("Parameter test is nil")
 	}
-
-	_, ok := test.ExecutionResults(commit)
-	testName := test.Test()
-	if !ok {
-		return nil, fmt.Errorf("Commit '%s' is not contained in TestResult for test '%s'", commit, test.Test())
+	if _, ok := test.ExecutionResults(commit); !ok {
+		return nil, fmt.Errorf("Commit '%s' is not contained in TestResult for test '%s'", commit, test.ID())
 	}
 
 	t, err := ChangePointTypeFromResult(commit, test)
 	if err != nil {
 		return nil, err
 	}
+	return NewChangePointOfType(commit, test, t)
+}
+
+// NewChangePointOfType creates a change point of a known type, e.g. of a stored change point
+func NewChangePointOfType(commit string, test TestResult, t ChangePointType) (ChangePoint, error) {
+	if test == nil {
+		return nil, fmt.Errorf("Parameter test is nil")
+	}
+
+	_, ok := test.ExecutionResults(commit)
+	testName := test.ID()
+	if !ok {
+		return nil, fmt.Errorf("Commit '%s' is not contained in TestResult for test '%s'", commit, testName)
+	}
 
 	return &cp{
 		C:   commit,
@@ -39,6 +50,8 @@ func NewChangePoint(commit string, test TestResult) (ChangePoint, error) {
 			testName: test,
 		},
 		T: t,
+		U: units(map[string]TestResult{testName: test}),
+		P: parameters(map[string]TestResult{testName: test}),
 	}, nil
 }
 
@@ -47,7 +60,42 @@ type cp struct {
 	Tns []string `json:"TestNames"`
 	ers map[string]TestResult
 	l   sync.RWMutex
-	T   ChangePointType `json:"Type"`
+	T   ChangePointType        `json:"Type"`
+	I   *CommitInfo            `json:"Info,omitempty"`
+	U   map[string]Unit        `json:"Units,omitempty"`
+	P   map[string][]Parameter `json:"Parameters,omitempty"`
+}
+
+// parameters returns the parameters of all parameterised tests
+func parameters(ers map[string]TestResult) map[string][]Parameter {
+	var ret map[string][]Parameter
+	for tn, tr := range ers {
+		ps := tr.Parameters()
+		if len(ps) == 0 {
+			continue
+		}
+		if ret == nil {
+			ret = make(map[string][]Parameter)
+		}
+		ret[tn] = ps
+	}
+	return ret
+}
+
+// units returns the units of all tests that have one
+func units(ers map[string]TestResult) map[string]Unit {
+	var ret map[string]Unit
+	for tn, tr := range ers {
+		u := tr.Unit()
+		if u.Name == "" {
+			continue
+		}
+		if ret == nil {
+			ret = make(map[string]Unit)
+		}
+		ret[tn] = u
+	}
+	return ret
 }
 
 func (c *cp) TestNames() []string {
@@ -89,9 +137,11 @@ func (c *cp) Add(commit string, test TestResult) error {
 		return ChangePointTypeError(fmt.Errorf("ChangePoint.Merge - types are not compatible: %v != %v", c.T, otherType))
 	}
 
-	testName := test.Test()
+	testName := test.ID()
 	c.Tns = append(c.Tns, testName)
 	c.ers[testName] = test
+	c.U = units(c.ers)
+	c.P = parameters(c.ers)
 	return nil
 }
 
@@ -150,6 +200,9 @@ func (c *cp) Merge(other ChangePoint) (ChangePoint, error) {
 		ers: m,
 		Tns: tns,
 		T:   c.T,
+		I:   c.I,
+		U:   units(m),
+		P:   parameters(m),
 	}, nil
 }
 
@@ -169,5 +222,56 @@ func (c *cp) Copy() ChangePoint {
 		Tns: tns,
 		ers: ers,
 		T:   c.T,
+		I:   c.I,
+		U:   units(ers),
+		P:   parameters(ers),
 	}
 }
+
+// WithTestNames returns a copy of change points whose tests are named by name (e.g. TestNamer) instead of their ID.
+// Tests of a change point with the same name are an error.
+func WithTestNames(cps ChangePoints, name func(TestResult) string) (ChangePoints, error) {
+	ret := NewChangePoints()
+	for _, c := range cps.All() {
+		oc, ok := c.(*cp)
+		if !ok {
+			return nil, fmt.Errorf("Unknown change point implementation %T", c)
+		}
+		nc, err := oc.withTestNames(name)
+		if err != nil {
+			return nil, err
+		}
+		err = ret.Add(nc)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return ret, nil
+}
+
+func (c *cp) withTestNames(name func(TestResult) string) (*cp, error) {
+	c.l.RLock()
+	defer c.l.RUnlock()
+
+	tns := make([]string, len(c.Tns))
+	ers := make(map[string]TestResult, len(c.Tns))
+	for i, tn := range c.Tns {
+		tr := c.ers[tn]
+		n := name(tr)
+		if _, ok := ers[n]; ok {
+			return nil, fmt.Errorf("Several tests named '%s' in change point of commit '%s'", n, c.C)
+		}
+		tns[i] = n
+		ers[n] = tr
+	}
+
+	return &cp{
+		C:   c.C,
+		Tns: tns,
+		ers: ers,
+		T:   c.T,
+		I:   c.I,
+		U:   units(ers),
+		P:   parameters(ers),
+	}, nil
+}
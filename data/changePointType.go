@@ -51,7 +51,7 @@ func ChangePointTypeFromResult(commit string, testResult TestResult) (ChangePoin
 		if c == commit {
 			if i == (l - 1) {
 				// last commit in test result
-				fmt.Printf("ERROR - ChangePoint as last commit (%s) of test result (%s)\n", c, testResult.Test())
+				fmt.Printf("ERROR - ChangePoint as last commit (%s) of test result (%s)\n", c, testResult.ID())
 				// should never happen
 				return cpt{}, nil
 			}
@@ -89,9 +89,15 @@ func ChangePointTypeFromResult(commit string, testResult TestResult) (ChangePoin
 		return nil, fmt.Errorf("NewChangePointType - error calculating mean: %v", err)
 	}
 
-	cc := calcChangeCategory(c1Mean, c2Mean)
+	// categories are relative changes of the original values
+	n := testResult.Normalisation()
+	cc := calcChangeCategory(n.Original(c1Mean), n.Original(c2Mean))
+	regression := c1Mean < c2Mean
+	if testResult.Unit().HigherIsBetter {
+		regression = c1Mean > c2Mean
+	}
 	return cpt{
-		regression: c1Mean < c2Mean,
+		regression: regression,
 		category:   cc,
 	}, nil
 }
@@ -0,0 +1,111 @@
+package data
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+)
+
+// MinAbbrevLength is the minimum length of an abbreviated SHA
+const MinAbbrevLength = 4
+
+// ErrUnknownCommit is returned for commits that resolve to no commit
+var ErrUnknownCommit = errors.New("Unknown commit")
+
+// CommitInfo is the metadata of a commit
+type CommitInfo struct {
+	SHA     string
+	Author  string
+	Date    time.Time
+	Subject string
+}
+
+// CommitInfos is the metadata of the commits of a repository, which resolves abbreviated SHAs
+type CommitInfos struct {
+	infos []CommitInfo
+	l     sync.RWMutex
+	// index in infos of resolved (possibly abbreviated) SHAs, -1 for unknown or ambiguous SHAs
+	m map[string]int
+}
+
+func NewCommitInfos(infos []CommitInfo) *CommitInfos {
+	ret := &CommitInfos{
+		infos: infos,
+		m:     make(map[string]int, len(infos)),
+	}
+	for i, ci := range infos {
+		ret.m[ci.SHA] = i
+	}
+	return ret
+}
+
+// Get returns the metadata of a (possibly abbreviated) commit. Unknown and ambiguous commits have no metadata.
+func (c *CommitInfos) Get(sha string) (CommitInfo, bool) {
+	if c == nil {
+		return CommitInfo{}, false
+	}
+	c.l.RLock()
+	i, ok := c.m[sha]
+	c.l.RUnlock()
+	if !ok {
+		i, _ = ResolveCommit(c.shas(), sha)
+		c.l.Lock()
+		c.m[sha] = i
+		c.l.Unlock()
+	}
+	if i < 0 {
+		return CommitInfo{}, false
+	}
+	return c.infos[i], true
+}
+
+func (c *CommitInfos) shas() []string {
+	ret := make([]string, len(c.infos))
+	for i, ci := range c.infos {
+		ret[i] = ci.SHA
+	}
+	return ret
+}
+
+// ResolveCommit returns the index of a (possibly abbreviated) commit in commits. Abbreviations must have at least
+// MinAbbrevLength characters and match exactly one commit.
+func ResolveCommit(commits []string, sha string) (int, error) {
+	for i, c := range commits {
+		if c == sha {
+			return i, nil
+		}
+	}
+
+	p := -1
+	for i, c := range commits {
+		if len(sha) < MinAbbrevLength || !strings.HasPrefix(c, sha) {
+			continue
+		}
+		if p >= 0 {
+			return -1, fmt.Errorf("Ambiguous commit '%s' matches '%s' and '%s'", sha, commits[p], c)
+		}
+		p = i
+	}
+	if p < 0 {
+		return -1, ErrUnknownCommit
+	}
+	return p, nil
+}
+
+// AttachCommitInfos returns a copy of change points with the metadata of their commits
+func AttachCommitInfos(cps ChangePoints, infos *CommitInfos) (ChangePoints, error) {
+	ret := NewChangePoints()
+	for _, c := range cps.All() {
+		nc := c.Copy()
+		if ci, ok := infos.Get(c.Commit()); ok {
+			nc.(*cp).I = &ci
+		}
+		err := ret.Add(nc)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return ret, nil
+}
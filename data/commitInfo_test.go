@@ -0,0 +1,63 @@
+package data
+
+import (
+	"testing"
+	"time"
+)
+
+func TestResolveCommit(t *testing.T) {
+	commits := []string{"abcdef01", "abcd9999", "1234abcd", "1234"}
+	tests := []struct {
+		sha string
+		pos int
+		err bool
+	}{
+		{"abcdef01", 0, false},
+		{"abcde", 0, false},
+		{"abcd9", 1, false},
+		{"1234", 3, false},
+		{"1234a", 2, false},
+		// ambiguous
+		{"abcd", -1, true},
+		// too short for an abbreviation
+		{"123", -1, true},
+		{"ffff", -1, true},
+	}
+
+	for _, test := range tests {
+		pos, err := ResolveCommit(commits, test.sha)
+		if pos != test.pos || (err != nil) != test.err {
+			t.Errorf("ResolveCommit(%q) = (%d, %v), expected position %d", test.sha, pos, err, test.pos)
+		}
+	}
+
+	if _, err := ResolveCommit(commits, "ffff"); err != ErrUnknownCommit {
+		t.Errorf("Expected ErrUnknownCommit, got %v", err)
+	}
+}
+
+func TestCommitInfos(t *testing.T) {
+	date := time.Date(2017, 3, 1, 12, 0, 0, 0, time.UTC)
+	infos := NewCommitInfos([]CommitInfo{
+		{SHA: "abcdef01", Author: "a", Date: date, Subject: "first"},
+		{SHA: "abcd9999", Author: "b", Date: date, Subject: "second"},
+	})
+
+	if ci, ok := infos.Get("abcdef01"); !ok || ci.Subject != "first" {
+		t.Errorf("Get of full SHA = (%v, %v)", ci, ok)
+	}
+	if ci, ok := infos.Get("abcd99"); !ok || ci.Subject != "second" {
+		t.Errorf("Get of abbreviated SHA = (%v, %v)", ci, ok)
+	}
+	if _, ok := infos.Get("abcd"); ok {
+		t.Errorf("Get of ambiguous SHA has metadata")
+	}
+	if _, ok := infos.Get("ffffffff"); ok {
+		t.Errorf("Get of unknown SHA has metadata")
+	}
+
+	var none *CommitInfos
+	if _, ok := none.Get("abcdef01"); ok {
+		t.Errorf("Get of nil infos has metadata")
+	}
+}
@@ -0,0 +1,39 @@
+package data
+
+import "fmt"
+
+// Commits returns the commits of all tests as a single sequence that is consistent with the commit order of the tests.
+// A commit that is not yet in the sequence is inserted after the preceding commit of its test.
+func Commits(trs TestResults) []string {
+	// linked list of the sequence with an index of its nodes; head is the (empty) start of the sequence
+	head := &commitNode{}
+	nodes := make(map[string]*commitNode)
+	for _, tn := range trs.TestNames() {
+		tr, ok := trs.Get(tn)
+		if !ok {
+			panic(fmt.Sprintf("TestNames and Get inconsistent for name '%s'", tn))
+		}
+		prev := head
+		for _, c := range tr.Commits() {
+			if n, ok := nodes[c]; ok {
+				prev = n
+				continue
+			}
+			n := &commitNode{commit: c, next: prev.next}
+			prev.next = n
+			nodes[c] = n
+			prev = n
+		}
+	}
+
+	seq := make([]string, 0, len(nodes))
+	for n := head.next; n != nil; n = n.next {
+		seq = append(seq, n.commit)
+	}
+	return seq
+}
+
+type commitNode struct {
+	commit string
+	next   *commitNode
+}
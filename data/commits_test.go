@@ -0,0 +1,35 @@
+package data
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestCommits(t *testing.T) {
+	trs := NewTestResults(DefaultHeading)
+	tests := []struct {
+		name    string
+		commits []string
+	}{
+		{"a", []string{"c1", "c3"}},
+		// c2 is inserted after c1, c4 after c3
+		{"b", []string{"c1", "c2", "c3", "c4"}},
+		// c0 is inserted at the start
+		{"c", []string{"c0", "c2"}},
+	}
+	for _, test := range tests {
+		for _, c := range test.commits {
+			err := trs.Add(&ExecutionResult{Project: "p", Version: c, SHA: c, Configuration: "default", Test: test.name, RawVal: 1})
+			if err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+
+	if seq := Commits(trs); !reflect.DeepEqual(seq, []string{"c0", "c1", "c2", "c3", "c4"}) {
+		t.Errorf("Commits = %v", seq)
+	}
+	if seq := Commits(NewTestResults(DefaultHeading)); len(seq) != 0 {
+		t.Errorf("Commits of no tests = %v", seq)
+	}
+}
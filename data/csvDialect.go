@@ -0,0 +1,316 @@
+package data
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+const (
+	FieldProject       = "Project"
+	FieldVersion       = "Version"
+	FieldSHA           = "SHA"
+	FieldConfiguration = "Configuration"
+	FieldTest          = "Test"
+	FieldRawVal        = "RawVal"
+	FieldTimestamp     = "Timestamp"
+	FieldUnit          = "Unit"
+	decimalPoint       = '.'
+)
+
+var ExecutionResultFields = []string{FieldProject, FieldVersion, FieldSHA, FieldConfiguration, FieldTest, FieldRawVal, FieldTimestamp, FieldUnit}
+
+// DefaultHeading is the heading of test results that are not read from a hopper CSV file
+var DefaultHeading = []string{FieldProject, FieldVersion, FieldSHA, FieldConfiguration, FieldTest, FieldRawVal}
+
+// CSVDialect describes the format of a CSV file with execution results.
+// Without Columns, the columns are in hopper order (project, version, SHA, configuration, test, value) and the
+// timestamp and unit are not available.
+// Columns maps ExecutionResult fields to column names of the heading or, with NoHeader, to column indexes.
+// Constants are values of ExecutionResult fields that are equal for all rows and override values of columns.
+// Metrics maps names of additional metrics to columns (names or indexes like Columns).
+type CSVDialect struct {
+	Comma     rune
+	Comment   rune
+	Decimal   rune
+	NoHeader  bool
+	Columns   map[string]string
+	Constants map[string]string
+	Metrics   map[string]string
+}
+
+func HopperDialect() CSVDialect {
+	return CSVDialect{
+		Comma:   sep,
+		Comment: comment,
+		Decimal: decimalPoint,
+	}
+}
+
+// Heading returns the heading to write for test results with heading h
+func (d CSVDialect) Heading(h []string) []string {
+	if d.NoHeader {
+		return nil
+	}
+	ret := h
+	if len(d.Columns) != 0 {
+		ret = make([]string, 0, len(d.Columns)+len(d.Metrics))
+		for _, f := range ExecutionResultFields {
+			if c, ok := d.Columns[f]; ok {
+				ret = append(ret, c)
+			}
+		}
+	}
+	// metric columns that are not yet part of the heading
+	for _, m := range d.metricNames() {
+		c := d.Metrics[m]
+		if indexOf(ret, c) == -1 {
+			ret = append(ret, c)
+		}
+	}
+	return ret
+}
+
+// metricNames returns the names of the metrics in lexical order
+func (d CSVDialect) metricNames() []string {
+	ret := make([]string, 0, len(d.Metrics))
+	for m := range d.Metrics {
+		ret = append(ret, m)
+	}
+	sort.Strings(ret)
+	return ret
+}
+
+// metricIndexes returns the column index of every metric of metricNames
+func (d CSVDialect) metricIndexes(heading []string) ([]int, error) {
+	ms := d.metricNames()
+	ret := make([]int, len(ms))
+	for i, m := range ms {
+		c := d.Metrics[m]
+		if d.NoHeader {
+			idx, err := strconv.Atoi(c)
+			if err != nil || idx < 0 {
+				return nil, fmt.Errorf("Column of metric '%s' is not a valid index: %s", m, c)
+			}
+			ret[i] = idx
+			continue
+		}
+		ret[i] = indexOf(heading, c)
+		if ret[i] == -1 {
+			return nil, fmt.Errorf("Column '%s' of metric '%s' not in heading %v", c, m, heading)
+		}
+	}
+	return ret, nil
+}
+
+func indexOf(heading []string, column string) int {
+	for i, h := range heading {
+		if strings.TrimSpace(h) == column {
+			return i
+		}
+	}
+	return -1
+}
+
+// indexes returns the column index of every field in ExecutionResultFields (-1 if not mapped to a column)
+func (d CSVDialect) indexes(heading []string) ([]int, error) {
+	ret := make([]int, len(ExecutionResultFields))
+	if len(d.Columns) == 0 {
+		for i := range ret {
+			ret[i] = -1
+			if i < len(DefaultHeading) {
+				ret[i] = i
+			}
+		}
+		return ret, nil
+	}
+
+	for i, f := range ExecutionResultFields {
+		c, ok := d.Columns[f]
+		if !ok {
+			ret[i] = -1
+			continue
+		}
+
+		if d.NoHeader {
+			idx, err := strconv.Atoi(c)
+			if err != nil || idx < 0 {
+				return nil, fmt.Errorf("Column of field '%s' is not a valid index: %s", f, c)
+			}
+			ret[i] = idx
+			continue
+		}
+
+		ret[i] = indexOf(heading, c)
+		if ret[i] == -1 {
+			return nil, fmt.Errorf("Column '%s' of field '%s' not in heading %v", c, f, heading)
+		}
+	}
+
+	for _, f := range []string{FieldSHA, FieldTest, FieldRawVal} {
+		_, col := d.Columns[f]
+		_, con := d.Constants[f]
+		if !col && !con {
+			return nil, fmt.Errorf("Field '%s' is neither mapped to a column nor a constant", f)
+		}
+	}
+	return ret, nil
+}
+
+func (d CSVDialect) parseFloat(s string) (float64, error) {
+	s = strings.TrimSpace(s)
+	if d.Decimal != 0 && d.Decimal != decimalPoint {
+		s = strings.Replace(s, string(d.Decimal), string(decimalPoint), 1)
+	}
+	return strconv.ParseFloat(s, 64)
+}
+
+func (d CSVDialect) formatFloat(f float64) string {
+	s := strconv.FormatFloat(f, 'f', -1, 64)
+	if d.Decimal != 0 && d.Decimal != decimalPoint {
+		s = strings.Replace(s, string(decimalPoint), string(d.Decimal), 1)
+	}
+	return s
+}
+
+// CSVFormat converts between records of a CSV file in a dialect and execution results
+type CSVFormat struct {
+	d             CSVDialect
+	indexes       []int
+	metrics       []string
+	metricIndexes []int
+	width         int
+}
+
+// NewCSVFormat creates a format for a file in dialect d with the heading h.
+// For writing, h must be the heading returned by d.Heading.
+func NewCSVFormat(d CSVDialect, h []string) (*CSVFormat, error) {
+	idx, err := d.indexes(h)
+	if err != nil {
+		return nil, err
+	}
+	midx, err := d.metricIndexes(h)
+	if err != nil {
+		return nil, err
+	}
+	width := 0
+	for _, i := range append(idx, midx...) {
+		if i >= width {
+			width = i + 1
+		}
+	}
+	return &CSVFormat{
+		d:             d,
+		indexes:       idx,
+		metrics:       d.metricNames(),
+		metricIndexes: midx,
+		width:         width,
+	}, nil
+}
+
+func (f *CSVFormat) field(record []string, i int) string {
+	if c, ok := f.d.Constants[ExecutionResultFields[i]]; ok {
+		return c
+	}
+	idx := f.indexes[i]
+	if idx == -1 {
+		return ""
+	}
+	return record[idx]
+}
+
+// ExecutionResult returns the execution result of a record, or an error if the record is invalid
+func (f *CSVFormat) ExecutionResult(record []string) (*ExecutionResult, error) {
+	if len(record) < f.width {
+		return nil, fmt.Errorf("Record has %d columns, expected at least %d", len(record), f.width)
+	}
+	v := f.field(record, 5)
+	rawVal, err := f.d.parseFloat(v)
+	if err != nil {
+		return nil, fmt.Errorf("Could not parse RawVal '%v' of test '%v' (%v)", v, f.field(record, 4), f.field(record, 2))
+	}
+	var metrics map[string]float64
+	for i, m := range f.metrics {
+		v := strings.TrimSpace(record[f.metricIndexes[i]])
+		if v == "" {
+			// metric not measured in this execution
+			continue
+		}
+		mv, err := f.d.parseFloat(v)
+		if err != nil {
+			return nil, fmt.Errorf("Could not parse metric '%s' '%v' of test '%v' (%v)", m, v, f.field(record, 4), f.field(record, 2))
+		}
+		if metrics == nil {
+			metrics = make(map[string]float64)
+		}
+		metrics[m] = mv
+	}
+	return &ExecutionResult{
+		Project:       f.field(record, 0),
+		Version:       f.field(record, 1),
+		SHA:           f.field(record, 2),
+		Configuration: f.field(record, 3),
+		Test:          f.field(record, 4),
+		RawVal:        rawVal,
+		Timestamp:     f.field(record, 6),
+		Unit:          f.field(record, 7),
+		Metrics:       metrics,
+	}, nil
+}
+
+// Record returns the record of an execution result
+func (f *CSVFormat) Record(er *ExecutionResult) []string {
+	vals := er.fields()
+	vals[5] = f.d.formatFloat(er.RawVal)
+	var ret []string
+	if len(f.d.Columns) == 0 {
+		ret = vals[:len(DefaultHeading)]
+	} else {
+		ret = make([]string, f.width)
+		for i, idx := range f.indexes {
+			if idx != -1 {
+				ret[idx] = vals[i]
+			}
+		}
+	}
+	if len(f.metrics) == 0 {
+		return ret
+	}
+
+	if len(ret) < f.width {
+		ret = append(ret, make([]string, f.width-len(ret))...)
+	}
+	for i, m := range f.metrics {
+		if v, ok := er.Metrics[m]; ok {
+			ret[f.metricIndexes[i]] = f.d.formatFloat(v)
+		}
+	}
+	return ret
+}
+
+// Records returns the record of an execution result and, if its named metrics are dispersion statistics, an
+// additional record of the test '<test>:<metric>' for every statistic without column in the dialect
+func (f *CSVFormat) Records(er *ExecutionResult) [][]string {
+	ret := [][]string{f.Record(er)}
+	if !er.Dispersion || len(er.Metrics) == 0 {
+		return ret
+	}
+
+	ms := make([]string, 0, len(er.Metrics))
+	for m := range er.Metrics {
+		if _, ok := f.d.Metrics[m]; !ok {
+			ms = append(ms, m)
+		}
+	}
+	sort.Strings(ms)
+	for _, m := range ms {
+		mer := *er
+		mer.Test = MetricTest(er.Test, m)
+		mer.RawVal = er.Metrics[m]
+		mer.Metrics = nil
+		mer.Dispersion = false
+		ret = append(ret, f.Record(&mer))
+	}
+	return ret
+}
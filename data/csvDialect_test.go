@@ -0,0 +1,189 @@
+package data
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestCSVFormatHopper(t *testing.T) {
+	d := HopperDialect()
+	cf, err := NewCSVFormat(d, d.Heading(DefaultHeading))
+	if err != nil {
+		t.Fatal(err)
+	}
+	rec := []string{"p", "1.0", "c1", "conf", "Bench.a", "1.5"}
+	er, err := cf.ExecutionResult(rec)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := &ExecutionResult{Project: "p", Version: "1.0", SHA: "c1", Configuration: "conf", Test: "Bench.a", RawVal: 1.5}
+	if !reflect.DeepEqual(er, expected) {
+		t.Errorf("expected %+v, got %+v", expected, er)
+	}
+	if out := cf.Record(er); !reflect.DeepEqual(out, rec) {
+		t.Errorf("expected record %v, got %v", rec, out)
+	}
+
+	if _, err := cf.ExecutionResult([]string{"p", "1.0", "c1"}); err == nil {
+		t.Errorf("expected error for short record")
+	}
+	if _, err := cf.ExecutionResult([]string{"p", "1.0", "c1", "", "a", "x"}); err == nil {
+		t.Errorf("expected error for invalid value")
+	}
+}
+
+func TestCSVFormatColumns(t *testing.T) {
+	d := CSVDialect{
+		Comma:   ',',
+		Decimal: ',',
+		Columns: map[string]string{
+			FieldSHA:    "commit",
+			FieldTest:   "benchmark",
+			FieldRawVal: "time",
+			FieldUnit:   "unit",
+		},
+		Constants: map[string]string{FieldProject: "gopper"},
+		Metrics:   map[string]string{"allocs": "allocations"},
+	}
+	heading := []string{"benchmark", "ignored", "time", "commit", "unit", "allocations"}
+	cf, err := NewCSVFormat(d, heading)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	er, err := cf.ExecutionResult([]string{"a", "x", "1,5", "c1", "ms", "3"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := &ExecutionResult{Project: "gopper", SHA: "c1", Test: "a", RawVal: 1.5, Unit: "ms", Metrics: map[string]float64{"allocs": 3}}
+	if !reflect.DeepEqual(er, expected) {
+		t.Errorf("expected %+v, got %+v", expected, er)
+	}
+
+	// empty metric cells are executions without the metric
+	er, err = cf.ExecutionResult([]string{"a", "x", "2", "c1", "ms", ""})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(er.Metrics) != 0 {
+		t.Errorf("expected no metrics, got %v", er.Metrics)
+	}
+
+	// written in the order of the dialect's heading with decimal comma
+	wh := d.Heading(DefaultHeading)
+	if exp := []string{"commit", "benchmark", "time", "unit", "allocations"}; !reflect.DeepEqual(wh, exp) {
+		t.Errorf("expected heading %v, got %v", exp, wh)
+	}
+	wf, err := NewCSVFormat(d, wh)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rec := wf.Record(&ExecutionResult{SHA: "c1", Test: "a", RawVal: 2.25, Unit: "ms", Metrics: map[string]float64{"allocs": 4}})
+	if exp := []string{"c1", "a", "2,25", "ms", "4"}; !reflect.DeepEqual(rec, exp) {
+		t.Errorf("expected record %v, got %v", exp, rec)
+	}
+}
+
+func TestCSVFormatNoHeader(t *testing.T) {
+	d := CSVDialect{
+		Comma:    ';',
+		NoHeader: true,
+		Columns:  map[string]string{FieldSHA: "2", FieldTest: "0", FieldRawVal: "1"},
+	}
+	cf, err := NewCSVFormat(d, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	er, err := cf.ExecutionResult([]string{"a", "3", "c1"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if er.Test != "a" || er.RawVal != 3 || er.SHA != "c1" {
+		t.Errorf("unexpected execution result %+v", er)
+	}
+
+	d.Columns[FieldTest] = "first"
+	if _, err := NewCSVFormat(d, nil); err == nil {
+		t.Errorf("expected error for column that is not an index")
+	}
+}
+
+func TestCSVFormatInvalidColumns(t *testing.T) {
+	tests := []struct {
+		name    string
+		columns map[string]string
+	}{
+		{"missing mandatory field", map[string]string{FieldSHA: "commit", FieldTest: "benchmark"}},
+		{"column not in heading", map[string]string{FieldSHA: "commit", FieldTest: "benchmark", FieldRawVal: "value"}},
+	}
+	for _, tt := range tests {
+		d := CSVDialect{Comma: ',', Columns: tt.columns}
+		if _, err := NewCSVFormat(d, []string{"commit", "benchmark", "time"}); err == nil {
+			t.Errorf("%s: expected error", tt.name)
+		}
+	}
+}
+
+func TestReadTestResultsDialect(t *testing.T) {
+	dir, err := ioutil.TempDir("", "gopper")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	p := filepath.Join(dir, "in.csv")
+	content := "commit\tbenchmark\ttime\n# comment\nc1\ta\t1\nc1\ta\tx\nc2\ta\t2\n"
+	if err := ioutil.WriteFile(p, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	d := CSVDialect{
+		Comma:   '\t',
+		Comment: '#',
+		Columns: map[string]string{FieldSHA: "commit", FieldTest: "benchmark", FieldRawVal: "time"},
+	}
+	trs, skipped, err := ReadTestResults(p, d, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(skipped) != 1 || skipped[0].Line != 4 {
+		t.Errorf("expected line 4 to be skipped, got %+v", skipped)
+	}
+	tr, ok := trs.Get("a")
+	if !ok {
+		t.Fatalf("test not read: %v", trs.TestNames())
+	}
+	if cs := tr.Commits(); !reflect.DeepEqual(cs, []string{"c1", "c2"}) {
+		t.Errorf("expected commits [c1 c2], got %v", cs)
+	}
+
+	if _, _, err := ReadTestResults(p, d, true); err == nil {
+		t.Errorf("expected error in strict mode")
+	}
+}
+
+func TestCSVFormatRecords(t *testing.T) {
+	d := HopperDialect()
+	cf, err := NewCSVFormat(d, d.Heading(DefaultHeading))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	er := &ExecutionResult{Project: "p", Version: "v", SHA: "c1", Configuration: "conf", Test: "a", RawVal: 1.5, Metrics: map[string]float64{"B/op": 16}}
+	if recs := cf.Records(er); len(recs) != 1 {
+		t.Errorf("expected no records of named metrics, got %v", recs)
+	}
+
+	er.Metrics = map[string]float64{"stddev": 0.5, "count": 3}
+	er.Dispersion = true
+	exp := [][]string{
+		{"p", "v", "c1", "conf", "a", "1.5"},
+		{"p", "v", "c1", "conf", "a:count", "3"},
+		{"p", "v", "c1", "conf", "a:stddev", "0.5"},
+	}
+	if recs := cf.Records(er); !reflect.DeepEqual(recs, exp) {
+		t.Errorf("expected records %v, got %v", exp, recs)
+	}
+}
@@ -1,30 +1,11 @@
 package data
 
 import (
-	"fmt"
 	"strconv"
+	"strings"
 	"sync"
 )
 
-func newExecutionResult(record []string) *ExecutionResult {
-	if len(record) < 6 {
-		return nil
-	}
-	rawVal, err := strconv.ParseFloat(record[5], 64)
-	if err != nil {
-		fmt.Printf("Could not parse RawVal (%v) of record (%v:%v)", record[5], record[2], record[4])
-		return nil
-	}
-	return &ExecutionResult{
-		record[0],
-		record[1],
-		record[2],
-		record[3],
-		record[4],
-		rawVal,
-	}
-}
-
 type ExecutionResult struct {
 	Project       string
 	Version       string
@@ -32,9 +13,24 @@ type ExecutionResult struct {
 	Configuration string
 	Test          string
 	RawVal        float64
+	// not part of hopper files
+	Timestamp string
+	Unit      string
+	// named metrics of the execution besides RawVal (e.g. allocated bytes)
+	Metrics map[string]float64
+	// units of named metrics, if known
+	MetricUnits map[string]string
+	// the named metrics are dispersion statistics of aggregated executions, which are written as additional records
+	Dispersion bool
 }
 
+// AsStringArray returns the fields in hopper order
 func (r ExecutionResult) AsStringArray() []string {
+	return r.fields()[:len(DefaultHeading)]
+}
+
+// fields returns the values of all ExecutionResultFields
+func (r ExecutionResult) fields() []string {
 	return []string{
 		r.Project,
 		r.Version,
@@ -42,9 +38,16 @@ func (r ExecutionResult) AsStringArray() []string {
 		r.Configuration,
 		r.Test,
 		strconv.FormatFloat(float64(r.RawVal), 'f', -1, 64),
+		r.Timestamp,
+		r.Unit,
 	}
 }
 
+// key identifies identical execution results
+func (r ExecutionResult) key() string {
+	return strings.Join(r.fields(), "\x00")
+}
+
 // ExecutionResults
 const (
 	defaultExecutionResultCount = 30
@@ -0,0 +1,73 @@
+package data
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+)
+
+type filterReasonsKey struct{}
+
+// FilterDecision records the transformation that filtered a test and the reason, e.g. the computed value
+type FilterDecision struct {
+	Project        string `json:",omitempty"`
+	Test           string
+	Configuration  string `json:",omitempty"`
+	Transformation string
+	Reason         string `json:",omitempty"`
+}
+
+// FilterReport collects the filter decisions of a transformation of test results
+type FilterReport struct {
+	l         sync.Mutex
+	decisions []FilterDecision
+}
+
+func NewFilterReport() *FilterReport {
+	return &FilterReport{}
+}
+
+func (r *FilterReport) Add(d FilterDecision) {
+	r.l.Lock()
+	defer r.l.Unlock()
+	r.decisions = append(r.decisions, d)
+}
+
+func (r *FilterReport) Decisions() []FilterDecision {
+	r.l.Lock()
+	defer r.l.Unlock()
+	ret := make([]FilterDecision, len(r.decisions))
+	copy(ret, r.decisions)
+	return ret
+}
+
+// FilterReasons collects the reasons why a test is filtered
+type FilterReasons struct {
+	l  sync.Mutex
+	rs []string
+}
+
+func (r *FilterReasons) String() string {
+	r.l.Lock()
+	defer r.l.Unlock()
+	return strings.Join(r.rs, "; ")
+}
+
+// WithFilterReasons returns a context that collects the reasons of FilterReason
+func WithFilterReasons(ctx context.Context) (context.Context, *FilterReasons) {
+	rs := &FilterReasons{}
+	return context.WithValue(ctx, filterReasonsKey{}, rs), rs
+}
+
+// FilterReason records why the test transformed with ctx is filtered, e.g. "mean 0.004 < 0.01". Transformations
+// call it before filtering a test.
+func FilterReason(ctx context.Context, format string, args ...interface{}) {
+	rs, ok := ctx.Value(filterReasonsKey{}).(*FilterReasons)
+	if !ok {
+		return
+	}
+	rs.l.Lock()
+	defer rs.l.Unlock()
+	rs.rs = append(rs.rs, fmt.Sprintf(format, args...))
+}
@@ -0,0 +1,138 @@
+package data
+
+import (
+	"context"
+	"fmt"
+)
+
+// GapPolicy decides how analyses handle versions of the version sequence that a test is missing (gaps)
+type GapPolicy int
+
+const (
+	// GapSkip analyses the versions of a test as one series, i.e. compares the versions around a gap
+	GapSkip GapPolicy = iota
+	// GapBreak analyses the series between gaps separately
+	GapBreak
+	// GapMax analyses tests without gaps larger than a maximum number of missing versions as one series
+	GapMax
+)
+
+// Gap is a sequence of versions of the version sequence that a test is missing between its versions Before and After
+type Gap struct {
+	Before  string
+	After   string
+	Missing []string
+}
+
+// Gaps returns the gaps of tr in the version sequence seq. Versions before the first and after the last version of tr
+// are not gaps.
+func Gaps(tr TestResult, seq []string) []Gap {
+	var ret []Gap
+	prev := -1
+	for i, c := range seq {
+		if _, ok := tr.ExecutionResults(c); !ok {
+			continue
+		}
+		if prev != -1 && i-prev > 1 {
+			ret = append(ret, Gap{
+				Before:  seq[prev],
+				After:   c,
+				Missing: seq[prev+1 : i],
+			})
+		}
+		prev = i
+	}
+	return ret
+}
+
+// gapsBetween returns the gaps whose versions Before and After are both versions of tr
+func gapsBetween(gaps []Gap, tr TestResult) []Gap {
+	var ret []Gap
+	for _, g := range gaps {
+		_, before := tr.ExecutionResults(g.Before)
+		_, after := tr.ExecutionResults(g.After)
+		if before && after {
+			ret = append(ret, g)
+		}
+	}
+	return ret
+}
+
+// AnalyseGaps returns an analysis function that applies f to the tests according to the gap policy, with the gaps
+// marked on the tests (see TestResult.Gaps). With GapBreak, change points at the last version before a gap are
+// removed, as they compare the versions around the gap. With GapMax, tests with gaps of more than maxGap versions are
+// not analysed.
+func AnalyseGaps(f AnalysisFunc, policy GapPolicy, maxGap int) AnalysisFunc {
+	return func(ctx context.Context, tr TestResult) (ChangePoints, error) {
+		if tr == nil {
+			return nil, fmt.Errorf("Parameter tr is nil")
+		}
+		gaps := tr.Gaps()
+		if len(gaps) == 0 {
+			return f(ctx, tr)
+		}
+
+		switch policy {
+		case GapSkip:
+			return f(ctx, tr)
+		case GapMax:
+			for _, g := range gaps {
+				if len(g.Missing) > maxGap {
+					fmt.Printf("WARN - Test '%s' not analysed: %d versions missing between '%s' and '%s' (maximum %d)\n", tr.ID(), len(g.Missing), g.Before, g.After, maxGap)
+					return NewChangePoints(), nil
+				}
+			}
+			return f(ctx, tr)
+		case GapBreak:
+			return analyseSeries(ctx, f, tr, gaps)
+		}
+		return nil, fmt.Errorf("Unknown gap policy %d", policy)
+	}
+}
+
+// analyseSeries applies f to the series of tr between the gaps
+func analyseSeries(ctx context.Context, f AnalysisFunc, tr TestResult, gaps []Gap) (ChangePoints, error) {
+	// last versions of the series
+	ends := make(map[string]struct{}, len(gaps))
+	for _, g := range gaps {
+		ends[g.Before] = struct{}{}
+	}
+
+	ret := NewChangePoints()
+	series := make([]string, 0)
+	commits := tr.Commits()
+	for i, c := range commits {
+		series = append(series, c)
+		_, end := ends[c]
+		if !end && i < len(commits)-1 {
+			continue
+		}
+
+		if len(series) > 1 {
+			s, err := Reorder(tr, series)
+			if err != nil {
+				return nil, err
+			}
+			cps, err := f(ctx, s)
+			if err != nil {
+				return nil, err
+			}
+			for _, cp := range cps.All() {
+				if cp.Commit() == series[len(series)-1] {
+					continue
+				}
+				// change points of the whole test
+				ncp, err := NewChangePoint(cp.Commit(), tr)
+				if err != nil {
+					return nil, err
+				}
+				err = ret.Add(ncp)
+				if err != nil {
+					return nil, err
+				}
+			}
+		}
+		series = make([]string, 0)
+	}
+	return ret, nil
+}
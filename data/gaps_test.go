@@ -0,0 +1,122 @@
+package data
+
+import (
+	"context"
+	"reflect"
+	"testing"
+)
+
+// gapTest returns a test with the values 10, 10, 20, 20, 30, 30 of the commits a0 - f0 without the missing commits,
+// with its gaps in the sequence a0 - f0
+func gapTest(t *testing.T, missing ...string) TestResult {
+	trs := NewTestResults(DefaultHeading)
+	skip := toStringSet(missing)
+	for i, v := range []float64{10, 10, 20, 20, 30, 30} {
+		if _, ok := skip[commitName(i)]; ok {
+			continue
+		}
+		err := trs.Add(&ExecutionResult{Project: "p", Version: "v", SHA: commitName(i), Configuration: "default", Test: "a", RawVal: v})
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	tr, _ := trs.Get(TestID("p", "a", "default"))
+	tr.SetGaps(Gaps(tr, []string{"a0", "b0", "c0", "d0", "e0", "f0"}))
+	return tr
+}
+
+func toStringSet(ss []string) map[string]struct{} {
+	ret := make(map[string]struct{}, len(ss))
+	for _, s := range ss {
+		ret[s] = struct{}{}
+	}
+	return ret
+}
+
+func TestGaps(t *testing.T) {
+	tr := gapTest(t, "a0", "c0", "d0")
+	expected := []Gap{{Before: "b0", After: "e0", Missing: []string{"c0", "d0"}}}
+	if g := tr.Gaps(); !reflect.DeepEqual(g, expected) {
+		t.Errorf("Gaps = %v, expected %v", g, expected)
+	}
+
+	// gaps are kept by copies and derived tests with the versions around the gap
+	if g := tr.Copy().Gaps(); !reflect.DeepEqual(g, expected) {
+		t.Errorf("Gaps of copy = %v", g)
+	}
+	r, err := Reorder(tr, []string{"b0", "e0"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if g := r.Gaps(); !reflect.DeepEqual(g, expected) {
+		t.Errorf("Gaps of reordered test = %v", g)
+	}
+	r, err = Reorder(tr, []string{"e0", "f0"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if g := r.Gaps(); len(g) != 0 {
+		t.Errorf("Gaps without the version before = %v", g)
+	}
+}
+
+// changeAt returns an analysis function that detects change points at every commit before a change of the mean
+func changeAt() AnalysisFunc {
+	return func(ctx context.Context, tr TestResult) (ChangePoints, error) {
+		ret := NewChangePoints()
+		commits := tr.Commits()
+		for i := 0; i < len(commits)-1; i++ {
+			ers1, _ := tr.ExecutionResults(commits[i])
+			ers2, _ := tr.ExecutionResults(commits[i+1])
+			if ers1.Values()[0] == ers2.Values()[0] {
+				continue
+			}
+			cp, err := NewChangePoint(commits[i], tr)
+			if err != nil {
+				return nil, err
+			}
+			if err := ret.Add(cp); err != nil {
+				return nil, err
+			}
+		}
+		return ret, nil
+	}
+}
+
+func changePointCommits(t *testing.T, f AnalysisFunc, tr TestResult) []string {
+	cps, err := f(context.Background(), tr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ret := []string{}
+	for _, cp := range cps.All() {
+		ret = append(ret, cp.Commit())
+	}
+	return ret
+}
+
+func TestAnalyseGaps(t *testing.T) {
+	// values 10, 10, -, -, 30, 30, i.e. the only change is around the gap
+	tr := gapTest(t, "c0", "d0")
+	tests := []struct {
+		name     string
+		f        AnalysisFunc
+		expected []string
+	}{
+		{"skip", AnalyseGaps(changeAt(), GapSkip, 0), []string{"b0"}},
+		{"break", AnalyseGaps(changeAt(), GapBreak, 0), []string{}},
+		{"maxGap 2", AnalyseGaps(changeAt(), GapMax, 2), []string{"b0"}},
+		{"maxGap 1", AnalyseGaps(changeAt(), GapMax, 1), []string{}},
+	}
+	for _, test := range tests {
+		if commits := changePointCommits(t, test.f, tr); !reflect.DeepEqual(commits, test.expected) {
+			t.Errorf("%s: change points at %v, expected %v", test.name, commits, test.expected)
+		}
+	}
+
+	// values 10, 10, 20, 20, -, 30: the change at b0 within the series remains, the one at d0 around the gap does not
+	tr = gapTest(t, "e0")
+	if commits := changePointCommits(t, AnalyseGaps(changeAt(), GapBreak, 0), tr); !reflect.DeepEqual(commits, []string{"b0"}) {
+		t.Errorf("break: change points at %v", commits)
+	}
+}
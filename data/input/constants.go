@@ -11,11 +11,62 @@ const (
 	FilterMinMean     = "minMean"
 	FilterMinVersions = "minVersions"
 	FilterMinMedian   = "minMedian"
+	FilterBaseName    = "baseName"
+	FilterParameter   = "parameter"
+	FilterInclude     = "includeTests"
+	FilterExclude     = "excludeTests"
+	FilterIncludeProj = "includeProjects"
+	FilterExcludeProj = "excludeProjects"
+	SliceRange        = "commitRange"
+	SliceLast         = "lastVersions"
+	SliceExclude      = "excludeCommits"
+	SampleMin         = "minExecutions"
+	SampleMax         = "maxExecutions"
+	Aggregate         = "aggregate"
+	NormaliseRatio    = "ratio"
+	NormaliseZScore   = "zscore"
+	NormaliseLog      = "log"
+	CpsAny            = "hasChangePoints"
+	CpsRegressions    = "regressions"
+	CpsMinCategory    = "minCategory"
+	CpsRange          = "changePointRange"
+	And               = "and"
+	Or                = "or"
+	Not               = "not"
 	AnalyseBcp        = "bcp"
 	AnalyseTwitter    = "twitter"
 	AnalyseTtest      = "ttest"
+	InHopper          = "hopper"
+	InGoBench         = "gobench"
+	InJUnit           = "junit"
+	InGoogleBenchmark = "googlebenchmark"
+	InPytestBenchmark = "pytestbenchmark"
+	InSQLite          = "sqlite"
+	OrderInsertion    = "insertion"
+	OrderSemVer       = "semver"
+	OrderList         = "list"
+	OrderTimestamp    = "timestamp"
+	OrderGit          = "git"
+	MergeAppend       = "append"
+	MergeDedup        = "dedup"
+	MergeFirst        = "first"
+	MergeLast         = "last"
+	MergeError        = "error"
+	GapsSkip          = "skip"
+	GapsBreak         = "break"
+	GapsMax           = "maxGap"
+	ReportCSV         = "csv"
+	ReportJSON        = "json"
 )
 
 var SubProgs = [...]string{SpPlot, SpFilter, SpMerge, SpAnalyse, SpTRsToCPs, SpSave, SpRmDupTns}
-var TransFuncs = [...]string{FilterMinMean, FilterMinMedian, FilterMinVersions}
+var TransFuncs = [...]string{FilterMinMean, FilterMinMedian, FilterMinVersions, FilterBaseName, FilterParameter, FilterInclude, FilterExclude, FilterIncludeProj, FilterExcludeProj, SliceRange, SliceLast, SliceExclude, SampleMin, SampleMax, Aggregate, NormaliseRatio, NormaliseZScore, NormaliseLog, CpsAny, CpsRegressions, CpsMinCategory, CpsRange, And, Or, Not}
+
+// FilterFuncs are the transformer functions that only filter tests (or change points), hence can be nested in combinators
+var FilterFuncs = [...]string{FilterMinMean, FilterMinMedian, FilterMinVersions, FilterBaseName, FilterParameter, FilterInclude, FilterExclude, FilterIncludeProj, FilterExcludeProj, CpsAny, CpsRegressions, CpsMinCategory, CpsRange, And, Or, Not}
 var AnalyseFuncs = [...]string{AnalyseBcp, AnalyseTwitter, AnalyseTtest}
+var OrderFuncs = [...]string{OrderInsertion, OrderSemVer, OrderList, OrderTimestamp, OrderGit}
+var MergeFuncs = [...]string{MergeAppend, MergeDedup, MergeFirst, MergeLast, MergeError}
+var GapFuncs = [...]string{GapsSkip, GapsBreak, GapsMax}
+var ReportFormats = [...]string{ReportCSV, ReportJSON}
+var InFormats = [...]string{InHopper, InGoBench, InJUnit, InGoogleBenchmark, InPytestBenchmark, InSQLite}
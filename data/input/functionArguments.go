@@ -91,3 +91,21 @@ func BoolParam(f Func, pos int) (bool, error) {
 		return false, fmt.Errorf("%s parameter is of incompatible type: %v", f.Name, reflect.TypeOf(p))
 	}
 }
+
+// StringParams returns all parameters from position pos on, which must be strings
+func StringParams(f Func, pos int) ([]string, error) {
+	err := checkParams("StringParams", f, pos)
+	if err != nil {
+		return nil, err
+	}
+
+	ret := make([]string, 0, len(f.Params)-pos)
+	for i := pos; i < len(f.Params); i++ {
+		s, err := StringParam(f, i)
+		if err != nil {
+			return nil, err
+		}
+		ret = append(ret, s)
+	}
+	return ret, nil
+}
@@ -1,15 +1,103 @@
 package input
 
+import "encoding/json"
+
 type Config struct {
-	In        []string
+	In        []In
 	Out       Out
 	Transform []Func
 	Analyse   Func
+	Git       Git
+	Order     Func
+	Merge     Func
+	// Gaps aligns the tests onto the version sequence of all tests and sets how analyses handle missing versions
+	Gaps Func
+	// Configurations selects and excludes configurations of all inputs
+	Configurations Configurations
+	// Projects configures transformations and analyses per project
+	Projects map[string]Project
+	// Units sets units and directions of tests by name
+	Units []Unit
+	// Metric selects a named metric (or all metrics) of the inputs instead of their values
+	Metric string
+	// Strict fails on input rows that can not be read instead of skipping them
+	Strict bool
+	// Quality configures the data-quality report of the inputs
+	Quality Quality
+}
+
+type Quality struct {
+	Path          string
+	MinExecutions int
+}
+
+// Unit sets the unit and/or direction of all tests whose ID matches the regular expression Tests
+type Unit struct {
+	Tests          string
+	Unit           string
+	HigherIsBetter *bool
+}
+
+type Project struct {
+	Transform []Func
+	Analyse   Func
+}
+
+type Configurations struct {
+	Include []string
+	Exclude []string
+}
+
+// Git is a local git repository whose first-parent history of Branch orders the commits
+type Git struct {
+	Path   string
+	Branch string
+}
+
+// In is either a path to a hopper CSV file or an object describing an input of another format
+type In struct {
+	Path    string
+	Format  string
+	Project string
+	Commit  string
+	// CSV dialect of hopper inputs
+	Delimiter string
+	Comment   string
+	Decimal   string
+	NoHeader  bool
+	Columns   map[string]string
+	Constants map[string]string
+	Metrics   map[string]string
+	// unit and direction of all tests of the input, overrides units of the importer
+	Unit           string
+	HigherIsBetter *bool
+	// query of sqlite inputs
+	Tests []string
+	From  string
+	To    string
+}
+
+func (in *In) UnmarshalJSON(b []byte) error {
+	var path string
+	if err := json.Unmarshal(b, &path); err == nil {
+		*in = In{Path: path}
+		return nil
+	}
+
+	type plainIn In
+	var pin plainIn
+	if err := json.Unmarshal(b, &pin); err != nil {
+		return err
+	}
+	*in = In(pin)
+	return nil
 }
 
 type Func struct {
 	Name   string
 	Params []interface{}
+	// nested functions of the combinators "and", "or" and "not"
+	Funcs []Func
 }
 
 type SubPrograms struct {
@@ -20,6 +108,9 @@ type SubPrograms struct {
 
 type Out struct {
 	TestResults  []string
+	KeepDialect  bool
 	ChangePoints []string
 	Plot         string
+	// format of the filter reports saved next to TestResults
+	FilterReport string
 }
@@ -3,21 +3,217 @@ package data
 import (
 	"context"
 	"fmt"
+	"strings"
 )
 
-func Merge(ctx context.Context, ins []TestResults) TestResults {
-	var r TestResults
-	for i, in := range ins {
-		if i == 0 {
-			r = NewTestResults(in.Heading())
+// MergeStrategy decides which executions are kept if a test has executions of the same commit in multiple inputs
+type MergeStrategy int
+
+const (
+	// MergeAppend keeps the executions of all inputs
+	MergeAppend MergeStrategy = iota
+	// MergeDedup drops executions that are identical to executions of a previous input
+	MergeDedup
+	// MergeFirst keeps the executions of the first input containing the commit
+	MergeFirst
+	// MergeLast keeps the executions of the last input containing the commit
+	MergeLast
+	// MergeError fails if the executions of the inputs differ
+	MergeError
+)
+
+// MergeSummary describes the overlaps of the merged inputs
+type MergeSummary struct {
+	Tests int
+	// tests contained in multiple inputs
+	OverlappingTests int
+	// commits of tests contained in multiple inputs
+	OverlappingCommits int
+	// executions that are identical to executions of a previous input
+	DuplicateExecutions int
+	// executions not kept by the merge strategy
+	DroppedExecutions int
+}
+
+func (s MergeSummary) String() string {
+	return fmt.Sprintf("%d tests, %d overlapping tests, %d overlapping versions, %d duplicate executions, %d dropped executions",
+		s.Tests, s.OverlappingTests, s.OverlappingCommits, s.DuplicateExecutions, s.DroppedExecutions)
+}
+
+// Merge merges test results of multiple inputs with the heading of the first input. All headings must be compatible.
+func Merge(ctx context.Context, ins []TestResults, strategy MergeStrategy) (TestResults, MergeSummary, error) {
+	var summary MergeSummary
+	if len(ins) == 0 {
+		return nil, summary, fmt.Errorf("No test results to merge")
+	}
+	heading := ins[0].Heading()
+	for i, in := range ins[1:] {
+		if !compatibleHeadings(heading, in.Heading()) {
+			return nil, summary, fmt.Errorf("Heading %v of input %d is not compatible with heading %v of input 1", in.Heading(), i+2, heading)
 		}
+	}
+
+	// tests in order of appearance with their test results per input
+	names := make([]string, 0)
+	tests := make(map[string][]TestResult)
+	for _, in := range ins {
 		for _, n := range in.TestNames() {
 			t, ok := in.Get(n)
 			if !ok {
 				panic(fmt.Sprintf("TestNames and Get inconsistent for name '%s'", n))
 			}
-			r.AddTest(t)
+			if _, ok := tests[n]; !ok {
+				names = append(names, n)
+			}
+			tests[n] = append(tests[n], t)
+		}
+	}
+
+	r := NewTestResults(heading)
+	for _, n := range names {
+		ts := tests[n]
+		if len(ts) == 1 {
+			r.AddTest(ts[0])
+			continue
+		}
+		summary.OverlappingTests++
+		t, err := mergeTest(ts, strategy, &summary)
+		if err != nil {
+			return nil, summary, err
+		}
+		r.AddTest(t)
+	}
+	summary.Tests = r.Len()
+	return r, summary, nil
+}
+
+// compatibleHeadings checks whether two headings have the same columns. The default heading of inputs that are not
+// hopper files is compatible with every heading.
+func compatibleHeadings(h1, h2 []string) bool {
+	if isDefaultHeading(h1) || isDefaultHeading(h2) {
+		return true
+	}
+	if len(h1) != len(h2) {
+		return false
+	}
+	for i := range h1 {
+		if !strings.EqualFold(strings.TrimSpace(h1[i]), strings.TrimSpace(h2[i])) {
+			return false
+		}
+	}
+	return true
+}
+
+func isDefaultHeading(h []string) bool {
+	if len(h) != len(DefaultHeading) {
+		return false
+	}
+	for i := range h {
+		if h[i] != DefaultHeading[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func mergeTest(ts []TestResult, strategy MergeStrategy, summary *MergeSummary) (TestResult, error) {
+	// commits in order of appearance with the executions per input
+	commits := make([]string, 0)
+	ers := make(map[string][][]*ExecutionResult)
+	for _, t := range ts {
+		for _, c := range t.Commits() {
+			e, ok := t.ExecutionResults(c)
+			if !ok {
+				panic(fmt.Sprintf("Commits and ExecutionResults inconsistent for commit '%s'", c))
+			}
+			if _, ok := ers[c]; !ok {
+				commits = append(commits, c)
+			}
+			ers[c] = append(ers[c], e.All())
+		}
+	}
+
+	ret := NewTestResultFrom(ts[0])
+	for _, c := range commits {
+		cers := ers[c]
+		if len(cers) > 1 {
+			summary.OverlappingCommits++
+		}
+		kept, err := mergeExecutions(cers, strategy, summary)
+		if err != nil {
+			return nil, fmt.Errorf("Could not merge test '%s' in commit '%s': %v", ts[0].ID(), c, err)
+		}
+		for _, er := range kept {
+			ret.AddExecutionResult(er)
+		}
+	}
+	return ret, nil
+}
+
+func mergeExecutions(ers [][]*ExecutionResult, strategy MergeStrategy, summary *MergeSummary) ([]*ExecutionResult, error) {
+	all := make([]*ExecutionResult, 0)
+	var unique []*ExecutionResult
+	// maximum number of identical executions in a previous input
+	seen := make(map[string]int)
+	for _, ier := range ers {
+		// the n-th identical execution of an input is a duplicate if a previous input has n identical executions
+		iseen := make(map[string]int)
+		for _, er := range ier {
+			all = append(all, er)
+			k := er.key()
+			if iseen[k] < seen[k] {
+				summary.DuplicateExecutions++
+			} else {
+				unique = append(unique, er)
+			}
+			iseen[k]++
+		}
+		for k, v := range iseen {
+			if v > seen[k] {
+				seen[k] = v
+			}
+		}
+	}
+
+	var kept []*ExecutionResult
+	switch strategy {
+	case MergeAppend:
+		kept = all
+	case MergeDedup:
+		kept = unique
+	case MergeFirst:
+		kept = ers[0]
+	case MergeLast:
+		kept = ers[len(ers)-1]
+	case MergeError:
+		for _, ier := range ers[1:] {
+			if !sameExecutions(ers[0], ier) {
+				return nil, fmt.Errorf("Executions of inputs differ")
+			}
+		}
+		kept = ers[0]
+	default:
+		return nil, fmt.Errorf("Unknown merge strategy %d", strategy)
+	}
+	summary.DroppedExecutions += len(all) - len(kept)
+	return kept, nil
+}
+
+// sameExecutions checks whether two lists of executions contain the same executions in any order
+func sameExecutions(ers1, ers2 []*ExecutionResult) bool {
+	if len(ers1) != len(ers2) {
+		return false
+	}
+	counts := make(map[string]int)
+	for _, er := range ers1 {
+		counts[er.key()]++
+	}
+	for _, er := range ers2 {
+		k := er.key()
+		if counts[k] == 0 {
+			return false
 		}
+		counts[k]--
 	}
-	return r
+	return true
 }
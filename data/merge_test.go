@@ -0,0 +1,107 @@
+package data
+
+import (
+	"context"
+	"reflect"
+	"testing"
+)
+
+// mergeInput returns test results of test 'a' with the values per commit
+func mergeInput(t *testing.T, values map[string][]float64, commits ...string) TestResults {
+	trs := NewTestResults(DefaultHeading)
+	for _, c := range commits {
+		for _, v := range values[c] {
+			err := trs.Add(&ExecutionResult{Project: "p", Version: c, SHA: c, Configuration: "default", Test: "a", RawVal: v})
+			if err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+	return trs
+}
+
+func TestMerge(t *testing.T) {
+	in1 := mergeInput(t, map[string][]float64{"c1": {1, 2}, "c2": {3}}, "c1", "c2")
+	in2 := mergeInput(t, map[string][]float64{"c2": {3, 4}, "c3": {5}}, "c2", "c3")
+
+	tests := []struct {
+		strategy MergeStrategy
+		c2       []float64
+		dropped  int
+	}{
+		{MergeAppend, []float64{3, 3, 4}, 0},
+		{MergeDedup, []float64{3, 4}, 1},
+		{MergeFirst, []float64{3}, 2},
+		{MergeLast, []float64{3, 4}, 1},
+	}
+
+	for _, test := range tests {
+		ret, summary, err := Merge(context.Background(), []TestResults{in1, in2}, test.strategy)
+		if err != nil {
+			t.Errorf("Merge(%d): %v", test.strategy, err)
+			continue
+		}
+		tr, ok := ret.Get("p/a@default")
+		if !ok {
+			t.Fatalf("Merge(%d) = %v", test.strategy, ret.TestNames())
+		}
+		if c := tr.Commits(); !reflect.DeepEqual(c, []string{"c1", "c2", "c3"}) {
+			t.Errorf("Merge(%d) commits = %v", test.strategy, c)
+		}
+		ers, _ := tr.ExecutionResults("c2")
+		if v := ers.Values(); !reflect.DeepEqual(v, test.c2) {
+			t.Errorf("Merge(%d) values of c2 = %v, expected %v", test.strategy, v, test.c2)
+		}
+		expected := MergeSummary{Tests: 1, OverlappingTests: 1, OverlappingCommits: 1, DuplicateExecutions: 1, DroppedExecutions: test.dropped}
+		if summary != expected {
+			t.Errorf("Merge(%d) summary = %+v, expected %+v", test.strategy, summary, expected)
+		}
+	}
+}
+
+func TestMergeError(t *testing.T) {
+	in1 := mergeInput(t, map[string][]float64{"c1": {1, 2}}, "c1")
+	same := mergeInput(t, map[string][]float64{"c1": {2, 1}}, "c1")
+	different := mergeInput(t, map[string][]float64{"c1": {1, 3}}, "c1")
+
+	if _, _, err := Merge(context.Background(), []TestResults{in1, same}, MergeError); err != nil {
+		t.Errorf("Merge of the same executions in another order: %v", err)
+	}
+	if _, _, err := Merge(context.Background(), []TestResults{in1, different}, MergeError); err == nil {
+		t.Errorf("Expected an error for different executions")
+	}
+}
+
+func TestMergeDedupRepeated(t *testing.T) {
+	// identical repetitions within an input are not duplicates, only the ones already in a previous input
+	in1 := mergeInput(t, map[string][]float64{"c1": {1, 1}}, "c1")
+	in2 := mergeInput(t, map[string][]float64{"c1": {1, 1, 1}}, "c1")
+	ret, summary, err := Merge(context.Background(), []TestResults{in1, in2}, MergeDedup)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tr, _ := ret.Get("p/a@default")
+	ers, _ := tr.ExecutionResults("c1")
+	if v := ers.Values(); !reflect.DeepEqual(v, []float64{1, 1, 1}) {
+		t.Errorf("Values = %v", v)
+	}
+	if summary.DuplicateExecutions != 2 {
+		t.Errorf("DuplicateExecutions = %d", summary.DuplicateExecutions)
+	}
+}
+
+func TestMergeHeadings(t *testing.T) {
+	in1 := NewTestResults([]string{"project", "version", "sha", "config", "test", "value"})
+	in2 := NewTestResults([]string{"Project", "Version", "SHA", "Config", "Test", "Value"})
+	other := NewTestResults([]string{"project", "sha", "test", "value"})
+
+	if _, _, err := Merge(context.Background(), []TestResults{in1, in2, NewTestResults(DefaultHeading)}, MergeAppend); err != nil {
+		t.Errorf("Merge of compatible headings: %v", err)
+	}
+	if _, _, err := Merge(context.Background(), []TestResults{in1, other}, MergeAppend); err == nil {
+		t.Errorf("Expected an error for incompatible headings")
+	}
+	if _, _, err := Merge(context.Background(), nil, MergeAppend); err == nil {
+		t.Errorf("Expected an error without inputs")
+	}
+}
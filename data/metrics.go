@@ -0,0 +1,125 @@
+package data
+
+import (
+	"fmt"
+	"sort"
+)
+
+const (
+	// AllMetrics selects the values and all named metrics of the execution results
+	AllMetrics      = "all"
+	metricTestTempl = "%s:%s"
+)
+
+// MetricTest returns the name of the test of a named metric
+func MetricTest(test, metric string) string {
+	return fmt.Sprintf(metricTestTempl, test, metric)
+}
+
+// SelectMetric returns test results with the values of a named metric, where every test becomes the test
+// '<test>:<metric>' with the unit of the metric. Execution results without the metric are dropped. With AllMetrics,
+// the test results contain the original tests and a separate test per named metric. The execution results of the
+// returned tests have no named metrics. A metric that no execution result has is an error.
+func SelectMetric(trs TestResults, metric string) (TestResults, error) {
+	if metric != AllMetrics {
+		known := MetricNames(trs)
+		if i := sort.SearchStrings(known, metric); i == len(known) || known[i] != metric {
+			return nil, fmt.Errorf("Unknown metric '%s', the test results have the metrics %v", metric, known)
+		}
+	}
+
+	ret := NewTestResults(trs.Heading())
+	for _, tn := range trs.TestNames() {
+		tr, ok := trs.Get(tn)
+		if !ok {
+			panic(fmt.Sprintf("TestNames and Get inconsistent for name '%s'", tn))
+		}
+
+		metrics := []string{metric}
+		if metric == AllMetrics {
+			t, err := Derive(tr, tr.Commits(), func(commit string) []*ExecutionResult {
+				ers, _ := tr.ExecutionResults(commit)
+				ret := make([]*ExecutionResult, 0, len(ers.All()))
+				for _, er := range ers.All() {
+					v := *er
+					v.Metrics = nil
+					v.MetricUnits = nil
+					v.Dispersion = false
+					ret = append(ret, &v)
+				}
+				return ret
+			})
+			if err != nil {
+				return nil, fmt.Errorf("Could not copy test '%s': %v", tn, err)
+			}
+			ret.AddTest(t)
+			metrics = metricNames(tr)
+		}
+		for _, m := range metrics {
+			addMetric(ret, tr, m)
+		}
+	}
+	return ret, nil
+}
+
+// MetricNames returns the names of the metrics of all tests in lexical order
+func MetricNames(trs TestResults) []string {
+	set := make(map[string]struct{})
+	for tr := range trs.All() {
+		for _, m := range metricNames(tr) {
+			set[m] = struct{}{}
+		}
+	}
+	ret := make([]string, 0, len(set))
+	for m := range set {
+		ret = append(ret, m)
+	}
+	sort.Strings(ret)
+	return ret
+}
+
+// metricNames returns the names of all metrics of a test in lexical order
+func metricNames(tr TestResult) []string {
+	set := make(map[string]struct{})
+	for _, c := range tr.Commits() {
+		ers, ok := tr.ExecutionResults(c)
+		if !ok {
+			continue
+		}
+		for _, er := range ers.All() {
+			for m := range er.Metrics {
+				set[m] = struct{}{}
+			}
+		}
+	}
+	ret := make([]string, 0, len(set))
+	for m := range set {
+		ret = append(ret, m)
+	}
+	sort.Strings(ret)
+	return ret
+}
+
+func addMetric(trs TestResults, tr TestResult, metric string) {
+	for _, c := range tr.Commits() {
+		ers, ok := tr.ExecutionResults(c)
+		if !ok {
+			continue
+		}
+		for _, er := range ers.All() {
+			v, ok := er.Metrics[metric]
+			if !ok {
+				continue
+			}
+			mer := *er
+			mer.Test = MetricTest(er.Test, metric)
+			mer.RawVal = v
+			// the unit of the execution is the unit of its value
+			mer.Unit = er.MetricUnits[metric]
+			mer.Metrics = nil
+			mer.MetricUnits = nil
+			mer.Dispersion = false
+			trs.Add(&mer)
+		}
+	}
+}
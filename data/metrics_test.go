@@ -0,0 +1,86 @@
+package data
+
+import (
+	"reflect"
+	"sort"
+	"testing"
+)
+
+func metricResults(t *testing.T) TestResults {
+	trs := NewTestResults(DefaultHeading)
+	for i, c := range []string{"c1", "c2"} {
+		err := trs.Add(&ExecutionResult{
+			Project: "p", Version: c, SHA: c, Configuration: "default", Test: "a", RawVal: float64(100 + i), Unit: "ns/op",
+			Metrics:     map[string]float64{"B/op": float64(8 + i), "allocs/op": 1},
+			MetricUnits: map[string]string{"B/op": "B/op"},
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	// a test without metrics
+	err := trs.Add(&ExecutionResult{Project: "p", Version: "c1", SHA: "c1", Configuration: "default", Test: "b", RawVal: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return trs
+}
+
+func TestSelectMetric(t *testing.T) {
+	trs := metricResults(t)
+	if ms := MetricNames(trs); !reflect.DeepEqual(ms, []string{"B/op", "allocs/op"}) {
+		t.Errorf("MetricNames = %v", ms)
+	}
+
+	ret, err := SelectMetric(trs, "B/op")
+	if err != nil {
+		t.Fatal(err)
+	}
+	// tests without the metric are dropped
+	if names := ret.TestNames(); !reflect.DeepEqual(names, []string{"p/a:B/op@default"}) {
+		t.Fatalf("TestNames = %v", names)
+	}
+	tr, _ := ret.Get("p/a:B/op@default")
+	ers, _ := tr.ExecutionResults("c2")
+	er := ers.All()[0]
+	if er.RawVal != 9 || er.Unit != "B/op" || er.Metrics != nil {
+		t.Errorf("Execution of metric = %+v", er)
+	}
+	if u := tr.Unit(); u.Name != "B/op" {
+		t.Errorf("Unit of metric test = %+v", u)
+	}
+
+	// a metric without unit has no unit instead of the unit of the value
+	ret, err = SelectMetric(trs, "allocs/op")
+	if err != nil {
+		t.Fatal(err)
+	}
+	tr, _ = ret.Get("p/a:allocs/op@default")
+	if ers, _ := tr.ExecutionResults("c1"); ers.All()[0].Unit != "" {
+		t.Errorf("Unit of metric without unit = %q", ers.All()[0].Unit)
+	}
+}
+
+func TestSelectAllMetrics(t *testing.T) {
+	ret, err := SelectMetric(metricResults(t), AllMetrics)
+	if err != nil {
+		t.Fatal(err)
+	}
+	names := ret.TestNames()
+	sort.Strings(names)
+	expected := []string{"p/a:B/op@default", "p/a:allocs/op@default", "p/a@default", "p/b@default"}
+	if !reflect.DeepEqual(names, expected) {
+		t.Errorf("TestNames = %v, expected %v", names, expected)
+	}
+	tr, _ := ret.Get("p/a@default")
+	ers, _ := tr.ExecutionResults("c1")
+	if er := ers.All()[0]; er.RawVal != 100 || er.Unit != "ns/op" || er.Metrics != nil {
+		t.Errorf("Execution of values = %+v", er)
+	}
+}
+
+func TestSelectUnknownMetric(t *testing.T) {
+	if _, err := SelectMetric(metricResults(t), "MB/s"); err == nil {
+		t.Errorf("Expected an error for an unknown metric")
+	}
+}
@@ -0,0 +1,53 @@
+package data
+
+import (
+	"fmt"
+	"math"
+)
+
+const (
+	NormalisationRatio  = "ratio"
+	NormalisationZScore = "zscore"
+	NormalisationLog    = "log"
+)
+
+// Normalisation describes how the values of a test are normalised, such that the original values can be restored.
+// The zero value is not normalised.
+type Normalisation struct {
+	Method string
+	// baseline commit of ratios
+	Baseline string
+	// original value of ratios and z-scores: value * Scale + Offset
+	Scale  float64
+	Offset float64
+}
+
+func (n Normalisation) IsNormalised() bool {
+	return n.Method != ""
+}
+
+// Original returns the original value of a normalised value
+func (n Normalisation) Original(v float64) float64 {
+	switch n.Method {
+	case NormalisationRatio, NormalisationZScore:
+		return v*n.Scale + n.Offset
+	case NormalisationLog:
+		return math.Exp(v)
+	default:
+		return v
+	}
+}
+
+// Label returns the label of normalised values of a unit
+func (n Normalisation) Label(unit string) string {
+	switch n.Method {
+	case NormalisationRatio:
+		return fmt.Sprintf("%s (ratio to %s)", unit, n.Baseline)
+	case NormalisationZScore:
+		return fmt.Sprintf("%s (z-score)", unit)
+	case NormalisationLog:
+		return fmt.Sprintf("log(%s)", unit)
+	default:
+		return unit
+	}
+}
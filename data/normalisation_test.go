@@ -0,0 +1,30 @@
+package data
+
+import (
+	"math"
+	"testing"
+)
+
+func TestNormalisation(t *testing.T) {
+	tests := []struct {
+		n       Normalisation
+		v, orig float64
+		label   string
+	}{
+		{Normalisation{}, 2, 2, "ns/op"},
+		{Normalisation{Method: NormalisationRatio, Baseline: "c1", Scale: 4}, 0.5, 2, "ns/op (ratio to c1)"},
+		{Normalisation{Method: NormalisationZScore, Scale: 2, Offset: 10}, -1, 8, "ns/op (z-score)"},
+		{Normalisation{Method: NormalisationLog}, 0, 1, "log(ns/op)"},
+	}
+	for _, test := range tests {
+		if o := test.n.Original(test.v); math.Abs(o-test.orig) > 1e-9 {
+			t.Errorf("%+v: Original(%v) = %v, expected %v", test.n, test.v, o, test.orig)
+		}
+		if l := test.n.Label("ns/op"); l != test.label {
+			t.Errorf("%+v: Label = %q, expected %q", test.n, l, test.label)
+		}
+		if normalised := test.n.IsNormalised(); normalised != (test.n.Method != "") {
+			t.Errorf("%+v: IsNormalised = %t", test.n, normalised)
+		}
+	}
+}
@@ -0,0 +1,63 @@
+package data
+
+import (
+	"strconv"
+	"strings"
+)
+
+const (
+	paramsStart    = "["
+	paramsEnd      = "]"
+	paramsSep      = ","
+	paramKeyValSep = "="
+)
+
+// Parameter is a parameter of a parameterised test, e.g. size=1000 of 'Bench.sort[size=1000, algo=quick]'
+type Parameter struct {
+	Key   string
+	Value string
+}
+
+func (p Parameter) String() string {
+	return p.Key + paramKeyValSep + p.Value
+}
+
+// ParseTestName splits the name of a parameterised test into its base name and parameters. A suffix after the
+// parameters (e.g. a metric) remains part of the base name. Parameters without key (e.g. 'test[1]') have their
+// position as key.
+func ParseTestName(name string) (string, []Parameter) {
+	start := strings.Index(name, paramsStart)
+	end := strings.LastIndex(name, paramsEnd)
+	if start == -1 || end < start {
+		return name, nil
+	}
+
+	base := name[:start] + name[end+1:]
+	ps := strings.Split(name[start+1:end], paramsSep)
+	params := make([]Parameter, 0, len(ps))
+	for i, p := range ps {
+		p = strings.TrimSpace(p)
+		kv := strings.SplitN(p, paramKeyValSep, 2)
+		if len(kv) == 2 {
+			params = append(params, Parameter{
+				Key:   strings.TrimSpace(kv[0]),
+				Value: strings.TrimSpace(kv[1]),
+			})
+		} else {
+			params = append(params, Parameter{
+				Key:   strconv.Itoa(i),
+				Value: p,
+			})
+		}
+	}
+	return base, params
+}
+
+// ParametersName joins parameters with sep, e.g. 'size=1000_algo=quick'
+func ParametersName(params []Parameter, sep string) string {
+	ps := make([]string, len(params))
+	for i, p := range params {
+		ps[i] = p.String()
+	}
+	return strings.Join(ps, sep)
+}
@@ -0,0 +1,50 @@
+package data
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseTestName(t *testing.T) {
+	tests := []struct {
+		name   string
+		base   string
+		params []Parameter
+	}{
+		{"Bench.sort", "Bench.sort", nil},
+		{"Bench.sort[size=1000, algo=quick]", "Bench.sort", []Parameter{{"size", "1000"}, {"algo", "quick"}}},
+		// parameters without key have their position as key
+		{"test_add[1-2]", "test_add", []Parameter{{"0", "1-2"}}},
+		{"test[a, b]", "test", []Parameter{{"0", "a"}, {"1", "b"}}},
+		// suffixes remain part of the base name
+		{"Bench.sort[size=10]:B/op", "Bench.sort:B/op", []Parameter{{"size", "10"}}},
+		// values may contain the key-value separator
+		{"Bench.q[expr=a=b]", "Bench.q", []Parameter{{"expr", "a=b"}}},
+		// no closing bracket after the opening bracket
+		{"Bench]x[", "Bench]x[", nil},
+	}
+	for _, test := range tests {
+		base, params := ParseTestName(test.name)
+		if base != test.base || !reflect.DeepEqual(params, test.params) {
+			t.Errorf("ParseTestName(%q) = (%q, %v), expected (%q, %v)", test.name, base, params, test.base, test.params)
+		}
+	}
+}
+
+func TestParametersName(t *testing.T) {
+	params := []Parameter{{"size", "1000"}, {"algo", "quick"}}
+	if n := ParametersName(params, "_"); n != "size=1000_algo=quick" {
+		t.Errorf("ParametersName = %q", n)
+	}
+
+	tr := NewTestResult("p", "Bench.sort[size=1000, algo=quick]", "")
+	if v, ok := tr.Parameter("algo"); !ok || v != "quick" {
+		t.Errorf("Parameter(algo) = (%q, %v)", v, ok)
+	}
+	if _, ok := tr.Parameter("threads"); ok {
+		t.Errorf("Parameter(threads) exists")
+	}
+	if tr.BaseName() != "Bench.sort" {
+		t.Errorf("BaseName = %q", tr.BaseName())
+	}
+}
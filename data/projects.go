@@ -0,0 +1,69 @@
+package data
+
+import "fmt"
+
+// ByProject splits test results into test results per project. The projects are returned in order of appearance.
+func ByProject(trs TestResults) ([]string, map[string]TestResults) {
+	projects := make([]string, 0)
+	ret := make(map[string]TestResults)
+	for _, n := range trs.TestNames() {
+		tr, ok := trs.Get(n)
+		if !ok {
+			panic(fmt.Sprintf("TestNames and Get inconsistent for name '%s'", n))
+		}
+		p := tr.Project()
+		ptrs, ok := ret[p]
+		if !ok {
+			ptrs = NewTestResults(trs.Heading())
+			ret[p] = ptrs
+			projects = append(projects, p)
+		}
+		ptrs.AddTest(tr)
+	}
+	return projects, ret
+}
+
+// Projects returns the projects of test results in order of appearance
+func Projects(trs TestResults) []string {
+	ret := make([]string, 0)
+	seen := make(map[string]struct{})
+	for tr := range trs.All() {
+		p := tr.Project()
+		if _, ok := seen[p]; !ok {
+			seen[p] = struct{}{}
+			ret = append(ret, p)
+		}
+	}
+	return ret
+}
+
+// Configurations returns the configurations of test results in order of appearance
+func Configurations(trs TestResults) []string {
+	ret := make([]string, 0)
+	seen := make(map[string]struct{})
+	for tr := range trs.All() {
+		c := tr.Configuration()
+		if _, ok := seen[c]; !ok {
+			seen[c] = struct{}{}
+			ret = append(ret, c)
+		}
+	}
+	return ret
+}
+
+// TestNamer returns how tests of test results are named in outputs. Tests are only namespaced by their project and
+// configuration (see TestResult.ID) if the test results contain several projects and configurations, respectively.
+func TestNamer(trs TestResults) func(TestResult) string {
+	withProject := len(Projects(trs)) > 1
+	withConfiguration := len(Configurations(trs)) > 1
+	return func(tr TestResult) string {
+		project, configuration := "", ""
+		if withProject {
+			project = tr.Project()
+		}
+		if withConfiguration {
+			configuration = tr.Configuration()
+		}
+		return TestID(project, tr.Test(), configuration)
+	}
+}
@@ -0,0 +1,114 @@
+package data
+
+import (
+	"reflect"
+	"testing"
+)
+
+// addTest adds a test with one execution per value, the i-th value in commit c<i>
+func addTest(t *testing.T, trs TestResults, project, test string, values ...float64) {
+	for i, v := range values {
+		err := trs.Add(&ExecutionResult{Project: project, Version: "v", SHA: commitName(i), Configuration: "default", Test: test, RawVal: v})
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func commitName(i int) string {
+	return string(rune('a'+i)) + "0"
+}
+
+func TestTestID(t *testing.T) {
+	tests := []struct {
+		project, test, configuration string
+		id                           string
+	}{
+		{"", "a", "", "a"},
+		{"p", "a", "", "p/a"},
+		{"", "a", "jdk8", "a@jdk8"},
+		{"p", "a", "jdk8", "p/a@jdk8"},
+	}
+	for _, test := range tests {
+		if id := TestID(test.project, test.test, test.configuration); id != test.id {
+			t.Errorf("TestID(%q, %q, %q) = %q, expected %q", test.project, test.test, test.configuration, id, test.id)
+		}
+	}
+
+	tr := NewTestResult("p", "a", "jdk8")
+	if tr.ID() != "p/a@jdk8" {
+		t.Errorf("ID = %q", tr.ID())
+	}
+}
+
+func TestTestNamer(t *testing.T) {
+	single := NewTestResults(DefaultHeading)
+	addTest(t, single, "p", "a", 1)
+	addTest(t, single, "p", "b", 1)
+	if ps := Projects(single); !reflect.DeepEqual(ps, []string{"p"}) {
+		t.Errorf("Projects = %v", ps)
+	}
+	tr, _ := single.Get("p/a@default")
+	if n := TestNamer(single)(tr); n != "a" {
+		t.Errorf("Name of a single project = %q", n)
+	}
+
+	multi := NewTestResults(DefaultHeading)
+	addTest(t, multi, "p", "a", 1)
+	addTest(t, multi, "q", "a", 1)
+	if multi.Len() != 2 {
+		t.Fatalf("Tests of different projects are not separate: %v", multi.TestNames())
+	}
+	if ps := Projects(multi); !reflect.DeepEqual(ps, []string{"p", "q"}) {
+		t.Errorf("Projects = %v", ps)
+	}
+	tr, _ = multi.Get("q/a@default")
+	if n := TestNamer(multi)(tr); n != "q/a" {
+		t.Errorf("Name of multiple projects = %q", n)
+	}
+
+	configurations := NewTestResults(DefaultHeading)
+	for _, c := range []string{"jdk8", "jdk11"} {
+		err := configurations.Add(&ExecutionResult{Project: "p", Version: "v", SHA: "a0", Configuration: c, Test: "a", RawVal: 1})
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	if cs := Configurations(configurations); !reflect.DeepEqual(cs, []string{"jdk8", "jdk11"}) && !reflect.DeepEqual(cs, []string{"jdk11", "jdk8"}) {
+		t.Errorf("Configurations = %v", cs)
+	}
+	tr, _ = configurations.Get("p/a@jdk11")
+	if n := TestNamer(configurations)(tr); n != "a@jdk11" {
+		t.Errorf("Name of multiple configurations = %q", n)
+	}
+}
+
+func TestWithTestNames(t *testing.T) {
+	trs := NewTestResults(DefaultHeading)
+	addTest(t, trs, "p", "a", 1, 2)
+	addTest(t, trs, "q", "a", 1, 2)
+
+	cps := NewChangePoints()
+	for tr := range trs.All() {
+		cp, err := NewChangePoint("a0", tr)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := cps.Add(cp); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	named, err := WithTestNames(cps, TestNamer(trs))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if tns := named.All()[0].TestNames(); !reflect.DeepEqual(tns, []string{"p/a", "q/a"}) && !reflect.DeepEqual(tns, []string{"q/a", "p/a"}) {
+		t.Errorf("TestNames = %v", tns)
+	}
+
+	// names without project collide
+	if _, err := WithTestNames(cps, TestResult.Test); err == nil {
+		t.Errorf("Expected an error for tests with the same name")
+	}
+}
@@ -0,0 +1,140 @@
+package data
+
+import (
+	"fmt"
+	"math"
+)
+
+const (
+	issueNaN      = "NaN"
+	issueInfinite = "infinite"
+	issueNegative = "negative"
+	issueZero     = "zero"
+)
+
+// SkippedRow is a row of an input file that could not be read. Importers of inputs with several files name the file
+// of the row, and formats without lines (e.g. XML and JSON reports) have no line.
+type SkippedRow struct {
+	Path   string `json:",omitempty"`
+	Line   int    `json:",omitempty"`
+	Reason string
+}
+
+// QualityIssue is an issue of the execution results of a test in a version, which occurs Count times
+type QualityIssue struct {
+	Test   string
+	Commit string
+	Issue  string `json:",omitempty"`
+	Count  int
+}
+
+// Quality is the data-quality report of an input
+type Quality struct {
+	Path       string
+	Tests      int
+	Executions int
+	// rows that could not be read
+	SkippedRows []SkippedRow
+	// NaN, infinite, negative and zero values
+	Values []QualityIssue
+	// execution results with the same values in all fields
+	DuplicateRows []QualityIssue
+	// versions with fewer than MinExecutions execution results
+	MinExecutions int
+	FewExecutions []QualityIssue
+	// tests with a single version
+	SingleVersion []string
+}
+
+// NewQuality creates the data-quality report of the test results read from path
+func NewQuality(path string, trs TestResults, skipped []SkippedRow, minExecutions int) Quality {
+	q := Quality{
+		Path:          path,
+		Tests:         trs.Len(),
+		SkippedRows:   skipped,
+		MinExecutions: minExecutions,
+	}
+	name := TestNamer(trs)
+	for _, id := range trs.TestNames() {
+		tr, ok := trs.Get(id)
+		if !ok {
+			panic(fmt.Sprintf("TestNames and Get inconsistent for name '%s'", id))
+		}
+		tn := name(tr)
+		commits := tr.Commits()
+		if len(commits) == 1 {
+			q.SingleVersion = append(q.SingleVersion, tn)
+		}
+		for _, c := range commits {
+			ers, ok := tr.ExecutionResults(c)
+			if !ok {
+				continue
+			}
+			all := ers.All()
+			q.Executions += len(all)
+			if len(all) < minExecutions {
+				q.FewExecutions = append(q.FewExecutions, QualityIssue{
+					Test:   tn,
+					Commit: c,
+					Count:  len(all),
+				})
+			}
+			q.addValueIssues(tn, c, all)
+			q.addDuplicates(tn, c, all)
+		}
+	}
+	return q
+}
+
+func (q *Quality) addValueIssues(test, commit string, ers []*ExecutionResult) {
+	counts := make(map[string]int)
+	for _, er := range ers {
+		if i := valueIssue(er.RawVal); i != "" {
+			counts[i]++
+		}
+	}
+	for _, i := range []string{issueNaN, issueInfinite, issueNegative, issueZero} {
+		if counts[i] > 0 {
+			q.Values = append(q.Values, QualityIssue{
+				Test:   test,
+				Commit: commit,
+				Issue:  i,
+				Count:  counts[i],
+			})
+		}
+	}
+}
+
+func valueIssue(v float64) string {
+	switch {
+	case math.IsNaN(v):
+		return issueNaN
+	case math.IsInf(v, 0):
+		return issueInfinite
+	case v < 0:
+		return issueNegative
+	case v == 0:
+		return issueZero
+	}
+	return ""
+}
+
+func (q *Quality) addDuplicates(test, commit string, ers []*ExecutionResult) {
+	seen := make(map[string]struct{})
+	dups := 0
+	for _, er := range ers {
+		k := er.key()
+		if _, ok := seen[k]; ok {
+			dups++
+			continue
+		}
+		seen[k] = struct{}{}
+	}
+	if dups > 0 {
+		q.DuplicateRows = append(q.DuplicateRows, QualityIssue{
+			Test:   test,
+			Commit: commit,
+			Count:  dups,
+		})
+	}
+}
@@ -0,0 +1,53 @@
+package data
+
+import (
+	"math"
+	"reflect"
+	"testing"
+)
+
+func TestNewQuality(t *testing.T) {
+	trs := NewTestResults(DefaultHeading)
+	ers := []ExecutionResult{
+		{Test: "a", SHA: "c1", RawVal: 1},
+		{Test: "a", SHA: "c1", RawVal: 1},
+		{Test: "a", SHA: "c1", RawVal: math.NaN()},
+		{Test: "a", SHA: "c2", RawVal: 0},
+		{Test: "a", SHA: "c2", RawVal: -1},
+		{Test: "a", SHA: "c2", RawVal: math.Inf(1)},
+		{Test: "b", SHA: "c1", RawVal: 2},
+	}
+	for i := range ers {
+		ers[i].Project = "p"
+		if err := trs.Add(&ers[i]); err != nil {
+			t.Fatal(err)
+		}
+	}
+	skipped := []SkippedRow{{Line: 3, Reason: "invalid"}}
+
+	q := NewQuality("in.csv", trs, skipped, 3)
+	if q.Tests != 2 || q.Executions != 7 || !reflect.DeepEqual(q.SkippedRows, skipped) {
+		t.Errorf("Quality = %+v", q)
+	}
+
+	values := []QualityIssue{
+		{Test: "a", Commit: "c1", Issue: issueNaN, Count: 1},
+		{Test: "a", Commit: "c2", Issue: issueInfinite, Count: 1},
+		{Test: "a", Commit: "c2", Issue: issueNegative, Count: 1},
+		{Test: "a", Commit: "c2", Issue: issueZero, Count: 1},
+	}
+	if !reflect.DeepEqual(q.Values, values) {
+		t.Errorf("Values = %+v, expected %+v", q.Values, values)
+	}
+	dups := []QualityIssue{{Test: "a", Commit: "c1", Count: 1}}
+	if !reflect.DeepEqual(q.DuplicateRows, dups) {
+		t.Errorf("DuplicateRows = %+v, expected %+v", q.DuplicateRows, dups)
+	}
+	few := []QualityIssue{{Test: "b", Commit: "c1", Count: 1}}
+	if !reflect.DeepEqual(q.FewExecutions, few) {
+		t.Errorf("FewExecutions = %+v, expected %+v", q.FewExecutions, few)
+	}
+	if !reflect.DeepEqual(q.SingleVersion, []string{"b"}) {
+		t.Errorf("SingleVersion = %v", q.SingleVersion)
+	}
+}
@@ -7,11 +7,30 @@ import (
 
 const (
 	defaultCommitCount = 30
+	// templates of test IDs
+	testIDProjectTemplate = "%s/%s"
+	testIDConfigTemplate  = "%s@%s"
 )
 
 type TestResult interface {
 	Project() string
 	Test() string
+	Configuration() string
+	// ID identifies the test within test results
+	ID() string
+	// BaseName and Parameters are the parts of the name of a parameterised test
+	BaseName() string
+	Parameters() []Parameter
+	Parameter(key string) (string, bool)
+	// Unit is the unit of the first execution result with a unit, unless set explicitly
+	Unit() Unit
+	SetUnit(u Unit)
+	// Normalisation of the values of the test, if they are normalised
+	Normalisation() Normalisation
+	SetNormalisation(n Normalisation)
+	// Gaps are the versions of the version sequence that the test is missing, if they are marked (see Gaps)
+	Gaps() []Gap
+	SetGaps(gaps []Gap)
 	Commits() []string
 	ExecutionResults(commit string) (ExecutionResults, bool)
 	AddExecutionResult(er *ExecutionResult) error
@@ -20,10 +39,26 @@ type TestResult interface {
 	Copy() TestResult
 }
 
-func NewTestResult(project, test string) TestResult {
+// TestID returns the ID of a test of a project in a configuration: [<project>/]<test>[@<configuration>]
+func TestID(project, test, configuration string) string {
+	id := test
+	if project != "" {
+		id = fmt.Sprintf(testIDProjectTemplate, project, id)
+	}
+	if configuration != "" {
+		id = fmt.Sprintf(testIDConfigTemplate, id, configuration)
+	}
+	return id
+}
+
+func NewTestResult(project, test, configuration string) TestResult {
+	base, params := ParseTestName(test)
 	return &testResultImpl{
 		project:          project,
 		test:             test,
+		baseName:         base,
+		parameters:       params,
+		configuration:    configuration,
 		executionResults: make(map[string]ExecutionResults),
 		commits:          make([]string, 0, defaultCommitCount),
 		changePoints:     NewChangePoints(),
@@ -34,6 +69,13 @@ type testResultImpl struct {
 	l                sync.RWMutex
 	project          string
 	test             string
+	baseName         string
+	parameters       []Parameter
+	configuration    string
+	unit             Unit
+	unitSet          bool
+	normalisation    Normalisation
+	gaps             []Gap
 	commits          []string
 	executionResults map[string]ExecutionResults
 	changePoints     ChangePoints
@@ -49,6 +91,71 @@ func (t *testResultImpl) Test() string {
 	return t.test
 }
 
+func (t *testResultImpl) BaseName() string {
+	// no locking as t.baseName is effectively immutable
+	return t.baseName
+}
+
+func (t *testResultImpl) Parameters() []Parameter {
+	// no locking as t.parameters is effectively immutable
+	return t.parameters
+}
+
+func (t *testResultImpl) Parameter(key string) (string, bool) {
+	for _, p := range t.parameters {
+		if p.Key == key {
+			return p.Value, true
+		}
+	}
+	return "", false
+}
+
+func (t *testResultImpl) Configuration() string {
+	// no locking as t.configuration is effectively immutable
+	return t.configuration
+}
+
+func (t *testResultImpl) ID() string {
+	return TestID(t.project, t.test, t.configuration)
+}
+
+func (t *testResultImpl) Unit() Unit {
+	t.l.RLock()
+	defer t.l.RUnlock()
+	return t.unit
+}
+
+func (t *testResultImpl) SetUnit(u Unit) {
+	t.l.Lock()
+	defer t.l.Unlock()
+	t.unit = u
+	t.unitSet = true
+}
+
+func (t *testResultImpl) Normalisation() Normalisation {
+	t.l.RLock()
+	defer t.l.RUnlock()
+	return t.normalisation
+}
+
+func (t *testResultImpl) SetNormalisation(n Normalisation) {
+	t.l.Lock()
+	defer t.l.Unlock()
+	t.normalisation = n
+}
+
+func (t *testResultImpl) Gaps() []Gap {
+	t.l.RLock()
+	defer t.l.RUnlock()
+	return t.gaps
+}
+
+func (t *testResultImpl) SetGaps(gaps []Gap) {
+	t.l.Lock()
+	defer t.l.Unlock()
+	t.gaps = gaps
+}
+
 func (t *testResultImpl) Commits() []string {
 	t.l.RLock()
 	defer t.l.RUnlock()
@@ -75,6 +182,10 @@ func (t *testResultImpl) AddExecutionResult(er *ExecutionResult) error {
 	t.l.Lock()
 	defer t.l.Unlock()
 
+	if !t.unitSet && t.unit.Name == "" && er.Unit != "" {
+		t.unit = NewUnit(er.Unit)
+	}
+
 	var contained bool
 	for _, c := range t.commits {
 		if c == er.SHA {
@@ -129,8 +240,99 @@ func (t *testResultImpl) Copy() TestResult {
 	return &testResultImpl{
 		project:          t.project,
 		test:             t.test,
+		baseName:         t.baseName,
+		parameters:       t.parameters,
+		configuration:    t.configuration,
+		unit:             t.unit,
+		unitSet:          t.unitSet,
+		normalisation:    t.normalisation,
+		gaps:             t.gaps,
 		commits:          commits,
 		executionResults: exRes,
 		changePoints:     t.changePoints.Copy(),
 	}
 }
+
+// NewTestResultFrom creates an empty test result with the identity and metadata (e.g. unit) of tr
+func NewTestResultFrom(tr TestResult) TestResult {
+	ret := NewTestResult(tr.Project(), tr.Test(), tr.Configuration())
+	ret.SetUnit(tr.Unit())
+	ret.SetNormalisation(tr.Normalisation())
+	ret.SetGaps(tr.Gaps())
+	return ret
+}
+
+// Reorder returns a copy of tr with only the given commits in the given order. Change points of remaining commits are
+// recreated, as their type depends on the succeeding commit.
+func Reorder(tr TestResult, commits []string) (TestResult, error) {
+	for _, c := range commits {
+		if _, ok := tr.ExecutionResults(c); !ok {
+			return nil, fmt.Errorf("Commit '%s' not in test result '%s'", c, tr.ID())
+		}
+	}
+	return Derive(tr, commits, func(commit string) []*ExecutionResult {
+		ers, _ := tr.ExecutionResults(commit)
+		return ers.All()
+	})
+}
+
+// Derive returns a copy of tr with the given commits and the execution results returned by ers for every commit.
+// Commits without execution results are omitted. Change points of remaining commits are recreated.
+func Derive(tr TestResult, commits []string, ers func(commit string) []*ExecutionResult) (TestResult, error) {
+	ret := NewTestResultFrom(tr)
+	kept := make([]string, 0, len(commits))
+	for _, c := range commits {
+		cers := ers(c)
+		if len(cers) == 0 {
+			continue
+		}
+		kept = append(kept, c)
+		for _, er := range cers {
+			ret.AddExecutionResult(er)
+		}
+	}
+	commits = kept
+	ret.SetGaps(gapsBetween(tr.Gaps(), ret))
+
+	last := len(commits) - 1
+	for _, cp := range tr.ChangePoints().All() {
+		c := cp.Commit()
+		isLast := last >= 0 && commits[last] == c
+		if _, ok := ret.ExecutionResults(c); !ok || isLast {
+			continue
+		}
+		ncp, err := NewChangePoint(c, ret)
+		if err != nil {
+			return nil, err
+		}
+		err = ret.AddChangePoint(ncp)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return ret, nil
+}
+
+// FilterChangePoints returns a copy of tr with only the change points for which keep is true
+func FilterChangePoints(tr TestResult, keep func(ChangePoint) bool) (TestResult, error) {
+	ret := NewTestResultFrom(tr)
+	for _, c := range tr.Commits() {
+		ers, ok := tr.ExecutionResults(c)
+		if !ok {
+			return nil, fmt.Errorf("Commit '%s' not in test result '%s'", c, tr.ID())
+		}
+		for _, er := range ers.All() {
+			ret.AddExecutionResult(er)
+		}
+	}
+	for _, cp := range tr.ChangePoints().All() {
+		if !keep(cp) {
+			continue
+		}
+		err := ret.AddChangePoint(cp)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return ret, nil
+}
@@ -1,12 +1,14 @@
 package data
 
 import (
+	"bufio"
 	"bytes"
 	"encoding/csv"
 	"fmt"
 	"io"
-	"os"
 	"sync"
+
+	"github.com/sealuzh/gopper/util"
 )
 
 const (
@@ -37,44 +39,104 @@ func NewTestResults(heading []string) TestResults {
 }
 
 func TestResultsFromFile(path string) (data TestResults, err error) {
-	f, err := os.Open(path)
+	return TestResultsFromDialect(path, HopperDialect())
+}
+
+func TestResultsFromDialect(path string, d CSVDialect) (data TestResults, err error) {
+	data, skipped, err := ReadTestResults(path, d, false)
+	for _, s := range skipped {
+		fmt.Printf("WARN - Skipped line %d of '%s': %s\n", s.Line, path, s.Reason)
+	}
+	return data, err
+}
+
+// ReadTestResults reads test results from a CSV file in dialect d and returns the rows that could not be read.
+// In strict mode, the first invalid row is an error.
+func ReadTestResults(path string, d CSVDialect, strict bool) (TestResults, []SkippedRow, error) {
+	f, err := util.Open(path)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 	defer f.Close()
 
 	// assume csv file
-	r := csv.NewReader(f)
-	r.Comma = rune(sep)
-	r.Comment = rune(comment)
+	lr := newLineReader(f)
+	r := csv.NewReader(lr)
+	r.Comma = d.Comma
+	r.Comment = d.Comment
 	r.LazyQuotes = true
 
-	// ignore first line
-	heading, err := r.Read()
+	heading := DefaultHeading
+	if !d.NoHeader {
+		// ignore first line
+		heading, err = r.Read()
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+	cf, err := NewCSVFormat(d, heading)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
+	if len(d.Columns) != 0 {
+		heading = DefaultHeading
+	}
+
 	res := NewTestResults(heading)
+	var skipped []SkippedRow
 	for {
 		rec, err := r.Read()
-		if err != nil {
-			if err != io.EOF {
-				fmt.Printf("ERROR - could not read CSV line: %v\n", err)
-			}
+		if err == io.EOF {
 			break
 		}
-		result := newExecutionResult(rec)
+		var result *ExecutionResult
+		if err == nil {
+			result, err = cf.ExecutionResult(rec)
+		} else if _, ok := err.(*csv.ParseError); !ok {
+			return nil, nil, err
+		}
 
-		if result == nil {
+		if err != nil {
+			if strict {
+				return nil, nil, fmt.Errorf("%s:%d: %v", path, lr.lines, err)
+			}
+			skipped = append(skipped, SkippedRow{
+				Line:   lr.lines,
+				Reason: err.Error(),
+			})
 			continue
 		}
 		res.Add(result)
 	}
+	return res, skipped, nil
+}
 
-	if err != nil {
-		return nil, err
+// lineReader returns at most one line per Read. As the CSV reader only reads if it needs more data, the number of
+// read lines is the line of the last record.
+type lineReader struct {
+	r     *bufio.Reader
+	rest  []byte
+	lines int
+}
+
+func newLineReader(r io.Reader) *lineReader {
+	return &lineReader{
+		r: bufio.NewReader(r),
+	}
+}
+
+func (l *lineReader) Read(p []byte) (int, error) {
+	if len(l.rest) == 0 {
+		line, err := l.r.ReadBytes('\n')
+		if len(line) == 0 {
+			return 0, err
+		}
+		l.lines++
+		l.rest = line
 	}
-	return res, nil
+	n := copy(p, l.rest)
+	l.rest = l.rest[n:]
+	return n, nil
 }
 
 type testResultsMap struct {
@@ -107,11 +169,12 @@ func (rm *testResultsMap) Add(r *ExecutionResult) error {
 
 	rm.lock.Lock()
 	defer rm.lock.Unlock()
-	res, ok := rm.m[r.Test]
+	id := TestID(r.Project, r.Test, r.Configuration)
+	res, ok := rm.m[id]
 	if !ok {
-		res = NewTestResult(r.Project, r.Test)
-		rm.m[r.Test] = res
-		rm.names = append(rm.names, r.Test)
+		res = NewTestResult(r.Project, r.Test, r.Configuration)
+		rm.m[id] = res
+		rm.names = append(rm.names, id)
 	}
 	res.AddExecutionResult(r)
 
@@ -126,7 +189,7 @@ func (rm *testResultsMap) AddTest(t TestResult) error {
 	rm.lock.Lock()
 	defer rm.lock.Unlock()
 
-	testName := t.Test()
+	testName := t.ID()
 	res, ok := rm.m[testName]
 	if ok {
 		for _, c := range t.Commits() {
@@ -3,37 +3,122 @@ package data
 import (
 	"context"
 	"fmt"
+	"runtime"
+	"sync"
 )
 
-type TransFunc func(context.Context, <-chan TestResult) <-chan TestResult
+// TransFunc transforms a test. It returns nil if the test is filtered, and an error if the test can not be
+// transformed, which filters the test as well.
+type TransFunc func(context.Context, TestResult) (TestResult, error)
+
+// Transformation is a transformation with the name it is reported with
+type Transformation struct {
+	Name string
+	Func TransFunc
+}
+
+// TransformWorkers is the number of tests transformed in parallel
+var TransformWorkers = runtime.NumCPU()
 
 func Transform(ctx context.Context, in TestResults, transformers ...TransFunc) TestResults {
-	ret := NewTestResults(in.Heading())
-	for _, r := range in.TestNames() {
-		tests, ok := in.Get(r)
-		if !ok {
-			panic(fmt.Sprintf("No element in results with name '%s'", r))
-		}
+	ts := make([]Transformation, len(transformers))
+	for i, t := range transformers {
+		ts[i] = Transformation{Func: t}
+	}
+	return TransformReport(ctx, in, ts, nil)
+}
 
-		ch := make(chan TestResult)
-		var c <-chan TestResult = ch
-		for _, transformer := range transformers {
-			c = transformer(ctx, c)
-		}
-		ch <- tests
-		// fmt.Printf("%d filtered results\n", len(tests.ExecutionResults))
-		select {
-		case results, ok := <-c:
-			// fmt.Printf("%d filtered results\n", len(results.ExecutionResults))
-			if ok {
-				if results != nil {
-					ret.AddTest(results)
+// TransformReport applies the transformations in order to every test of in, with up to TransformWorkers tests in
+// parallel. The transformation that filtered a test, with its reason, is added to report (if not nil). Tests that
+// can not be transformed (including transformations that panic) are filtered and reported as well.
+func TransformReport(ctx context.Context, in TestResults, transformations []Transformation, report *FilterReport) TestResults {
+	names := in.TestNames()
+	results := make([]TestResult, len(names))
+	decisions := make([]*FilterDecision, len(names))
+
+	workers := TransformWorkers
+	if workers < 1 {
+		workers = 1
+	}
+	idxs := make(chan int)
+	var wg sync.WaitGroup
+	wg.Add(workers)
+	for w := 0; w < workers; w++ {
+		go func() {
+			defer wg.Done()
+			for i := range idxs {
+				tests, ok := in.Get(names[i])
+				if !ok {
+					// should not happen
+					fmt.Printf("ERROR - No element in results with name '%s'\n", names[i])
+					decisions[i] = &FilterDecision{Test: names[i], Reason: "not in test results"}
+					continue
 				}
+				results[i], decisions[i] = transformTest(ctx, tests, transformations)
 			}
+		}()
+	}
+
+Loop:
+	for i := range names {
+		select {
+		case idxs <- i:
 		case <-ctx.Done():
-			break
+			break Loop
+		}
+	}
+	close(idxs)
+	wg.Wait()
+
+	// tests and decisions in the order of in
+	ret := NewTestResults(in.Heading())
+	for i, r := range results {
+		if r != nil {
+			ret.AddTest(r)
+		}
+		if report != nil && decisions[i] != nil {
+			report.Add(*decisions[i])
 		}
 	}
 	fmt.Printf("  %d/%d not filtered\n", ret.Len(), in.Len())
 	return ret
 }
+
+// transformTest applies the transformations to tests. If one of them filters the test, it returns nil and the
+// filter decision.
+func transformTest(ctx context.Context, tests TestResult, transformations []Transformation) (TestResult, *FilterDecision) {
+	results := tests
+	for _, t := range transformations {
+		if ctx.Err() != nil {
+			return nil, nil
+		}
+		tctx, reasons := WithFilterReasons(ctx)
+		r, err := apply(tctx, t.Func, results)
+		if err != nil {
+			fmt.Printf("ERROR - Could not transform test '%s' with '%s': %v\n", tests.ID(), t.Name, err)
+			FilterReason(tctx, "%v", err)
+			r = nil
+		}
+		if r == nil {
+			return nil, &FilterDecision{
+				Project:        tests.Project(),
+				Test:           tests.Test(),
+				Configuration:  tests.Configuration(),
+				Transformation: t.Name,
+				Reason:         reasons.String(),
+			}
+		}
+		results = r
+	}
+	return results, nil
+}
+
+// apply returns the result of f for tests. A panic of f is returned as error.
+func apply(ctx context.Context, f TransFunc, tests TestResult) (ret TestResult, err error) {
+	defer func() {
+		if p := recover(); p != nil {
+			ret, err = nil, fmt.Errorf("Panic: %v", p)
+		}
+	}()
+	return f(ctx, tests)
+}
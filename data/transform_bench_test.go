@@ -0,0 +1,111 @@
+package data
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/montanaflynn/stats"
+)
+
+// benchTests is the number of tests of the benchmarks, in the order of magnitude of large inputs
+const benchTests = 20000
+
+func benchTestResults(b *testing.B) TestResults {
+	trs := NewTestResults(DefaultHeading)
+	for i := 0; i < benchTests; i++ {
+		for c := 0; c < 5; c++ {
+			for e := 0; e < 3; e++ {
+				err := trs.Add(&ExecutionResult{Project: "p", Version: "v", SHA: commitName(c), Configuration: "default", Test: fmt.Sprintf("Bench%d", i), RawVal: float64(i%100 + e)})
+				if err != nil {
+					b.Fatal(err)
+				}
+			}
+		}
+	}
+	return trs
+}
+
+// benchMean is the mean of all values of a test
+func benchMean(tests TestResult) float64 {
+	var sum float64
+	for _, c := range tests.Commits() {
+		ers, _ := tests.ExecutionResults(c)
+		m, _ := stats.Mean(stats.Float64Data(ers.Values()))
+		sum += m
+	}
+	return sum / float64(len(tests.Commits()))
+}
+
+// chanTransFunc is a transformation of the channel chain that transformations were before the worker pool, with a
+// goroutine and channel per test and transformation
+type chanTransFunc func(context.Context, <-chan TestResult) <-chan TestResult
+
+func chanMinMean(min float64) chanTransFunc {
+	return func(ctx context.Context, in <-chan TestResult) <-chan TestResult {
+		out := make(chan TestResult)
+		go func() {
+			defer close(out)
+			tests, ok := <-in
+			if !ok {
+				return
+			}
+			if benchMean(tests) < min {
+				FilterReason(ctx, "mean < %v", min)
+				out <- nil
+				return
+			}
+			out <- tests
+		}()
+		return out
+	}
+}
+
+func chanTransform(ctx context.Context, in TestResults, fs []chanTransFunc) TestResults {
+	ret := NewTestResults(in.Heading())
+	for _, n := range in.TestNames() {
+		results, _ := in.Get(n)
+		for _, f := range fs {
+			tctx, _ := WithFilterReasons(ctx)
+			ch := make(chan TestResult, 1)
+			ch <- results
+			close(ch)
+			results = <-f(tctx, ch)
+			if results == nil {
+				break
+			}
+		}
+		if results != nil {
+			ret.AddTest(results)
+		}
+	}
+	return ret
+}
+
+func minMean(min float64) TransFunc {
+	return func(ctx context.Context, tests TestResult) (TestResult, error) {
+		if benchMean(tests) < min {
+			FilterReason(ctx, "mean < %v", min)
+			return nil, nil
+		}
+		return tests, nil
+	}
+}
+
+func BenchmarkTransformChannels(b *testing.B) {
+	trs := benchTestResults(b)
+	fs := []chanTransFunc{chanMinMean(10), chanMinMean(20), chanMinMean(50)}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		chanTransform(context.Background(), trs, fs)
+	}
+}
+
+func BenchmarkTransformWorkers(b *testing.B) {
+	trs := benchTestResults(b)
+	ts := []Transformation{{Name: "10", Func: minMean(10)}, {Name: "20", Func: minMean(20)}, {Name: "50", Func: minMean(50)}}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		TransformReport(context.Background(), trs, ts, NewFilterReport())
+	}
+}
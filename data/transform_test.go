@@ -0,0 +1,86 @@
+package data
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+// minValue filters tests whose first value is below min
+func minValue(min float64) TransFunc {
+	return func(ctx context.Context, tests TestResult) (TestResult, error) {
+		ers, _ := tests.ExecutionResults(tests.Commits()[0])
+		if v := ers.Values()[0]; v < min {
+			FilterReason(ctx, "%v < %v", v, min)
+			return nil, nil
+		}
+		return tests, nil
+	}
+}
+
+func TestTransformReport(t *testing.T) {
+	trs := NewTestResults(DefaultHeading)
+	addTest(t, trs, "p", "a", 1)
+	addTest(t, trs, "p", "b", 5)
+	addTest(t, trs, "q", "c", 10)
+
+	report := NewFilterReport()
+	ret := TransformReport(context.Background(), trs, []Transformation{
+		{Name: "min 2", Func: minValue(2)},
+		{Name: "fails", Func: func(ctx context.Context, tests TestResult) (TestResult, error) {
+			if tests.Project() == "q" {
+				return nil, errors.New("Failed")
+			}
+			return tests, nil
+		}},
+		{Name: "min 6", Func: minValue(6)},
+	}, report)
+
+	if ret.Len() != 0 {
+		t.Errorf("Tests not filtered: %v", ret.TestNames())
+	}
+	expected := []FilterDecision{
+		{Project: "p", Test: "a", Configuration: "default", Transformation: "min 2", Reason: "1 < 2"},
+		{Project: "p", Test: "b", Configuration: "default", Transformation: "min 6", Reason: "5 < 6"},
+		{Project: "q", Test: "c", Configuration: "default", Transformation: "fails", Reason: "Failed"},
+	}
+	if ds := report.Decisions(); !reflect.DeepEqual(ds, expected) {
+		t.Errorf("Decisions = %v, expected %v", ds, expected)
+	}
+}
+
+func TestTransformKept(t *testing.T) {
+	trs := NewTestResults(DefaultHeading)
+	addTest(t, trs, "p", "a", 1, 2)
+	addTest(t, trs, "p", "b", 3)
+
+	ret := Transform(context.Background(), trs, minValue(0))
+	if names := ret.TestNames(); !reflect.DeepEqual(names, trs.TestNames()) {
+		t.Errorf("TestNames = %v, expected %v", names, trs.TestNames())
+	}
+}
+
+func TestTransformPanic(t *testing.T) {
+	trs := NewTestResults(DefaultHeading)
+	addTest(t, trs, "p", "a", 1)
+	addTest(t, trs, "p", "b", 5)
+
+	report := NewFilterReport()
+	ret := TransformReport(context.Background(), trs, []Transformation{
+		{Name: "panics", Func: func(ctx context.Context, tests TestResult) (TestResult, error) {
+			if tests.Test() == "a" {
+				panic("index out of range")
+			}
+			return tests, nil
+		}},
+	}, report)
+
+	if names := ret.TestNames(); !reflect.DeepEqual(names, []string{"p/b@default"}) {
+		t.Errorf("TestNames = %v", names)
+	}
+	expected := []FilterDecision{{Project: "p", Test: "a", Configuration: "default", Transformation: "panics", Reason: "Panic: index out of range"}}
+	if ds := report.Decisions(); !reflect.DeepEqual(ds, expected) {
+		t.Errorf("Decisions = %v, expected %v", ds, expected)
+	}
+}
@@ -0,0 +1,35 @@
+package data
+
+import "strings"
+
+// Unit is the unit of the values of a test and whether higher values are better (e.g. throughput) or worse (e.g. time)
+type Unit struct {
+	Name           string
+	HigherIsBetter bool
+}
+
+// time units that make a unit a rate if they are its denominator (e.g. ops/s, MB/s)
+var rateDenominators = []string{"ns", "us", "µs", "ms", "s", "sec", "min", "h"}
+
+// NewUnit creates a unit whose direction is derived from its name. Rates (per time unit) are higher is better,
+// everything else (e.g. ns/op, B/op, s) is lower is better.
+func NewUnit(name string) Unit {
+	return Unit{
+		Name:           name,
+		HigherIsBetter: isRate(name),
+	}
+}
+
+func isRate(unit string) bool {
+	i := strings.LastIndex(unit, "/")
+	if i == -1 {
+		return false
+	}
+	den := strings.TrimSpace(unit[i+1:])
+	for _, d := range rateDenominators {
+		if den == d {
+			return true
+		}
+	}
+	return false
+}
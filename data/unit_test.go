@@ -0,0 +1,24 @@
+package data
+
+import "testing"
+
+func TestNewUnit(t *testing.T) {
+	tests := []struct {
+		name           string
+		higherIsBetter bool
+	}{
+		{"ns/op", false},
+		{"B/op", false},
+		{"s", false},
+		{"ops/s", true},
+		{"MB/s", true},
+		{"ops/ms", true},
+		{"requests / min", true},
+		{"", false},
+	}
+	for _, test := range tests {
+		if u := NewUnit(test.name); u.Name != test.name || u.HigherIsBetter != test.higherIsBetter {
+			t.Errorf("NewUnit(%q) = %+v, expected HigherIsBetter %v", test.name, u, test.higherIsBetter)
+		}
+	}
+}
@@ -0,0 +1,28 @@
+package load
+
+import (
+	"bufio"
+	"strings"
+
+	"github.com/sealuzh/gopper/util"
+)
+
+// CommitList reads an ordered list of commits, one per line. Empty lines and lines starting with '#' are ignored.
+func CommitList(path string) ([]string, error) {
+	f, err := util.Open(util.AbsolutePath(path))
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	ret := make([]string, 0)
+	s := bufio.NewScanner(f)
+	for s.Scan() {
+		l := strings.TrimSpace(s.Text())
+		if l == "" || strings.HasPrefix(l, "#") {
+			continue
+		}
+		ret = append(ret, l)
+	}
+	return ret, s.Err()
+}
@@ -0,0 +1,81 @@
+package load
+
+import (
+	"fmt"
+	"unicode/utf8"
+
+	"github.com/sealuzh/gopper/data"
+	"github.com/sealuzh/gopper/data/input"
+	"github.com/sealuzh/gopper/util"
+)
+
+// Dialect returns the CSV dialect of an input, where unset elements are taken from the hopper dialect
+func Dialect(in input.In) (data.CSVDialect, error) {
+	d := data.HopperDialect()
+	var err error
+	d.Comma, err = dialectRune("Delimiter", in.Delimiter, d.Comma)
+	if err != nil {
+		return d, err
+	}
+	d.Comment, err = dialectRune("Comment", in.Comment, d.Comment)
+	if err != nil {
+		return d, err
+	}
+	d.Decimal, err = dialectRune("Decimal", in.Decimal, d.Decimal)
+	if err != nil {
+		return d, err
+	}
+	d.NoHeader = in.NoHeader
+	d.Columns = in.Columns
+	d.Constants = in.Constants
+	d.Metrics = in.Metrics
+
+	for _, m := range []map[string]string{d.Columns, d.Constants} {
+		for f := range m {
+			if !isExecutionResultField(f) {
+				return d, fmt.Errorf("Unknown field '%s'. Must be one of %v", f, data.ExecutionResultFields)
+			}
+		}
+	}
+	return d, nil
+}
+
+func dialectRune(name, s string, def rune) (rune, error) {
+	if s == "" {
+		return def, nil
+	}
+	if s == `\t` {
+		return '\t', nil
+	}
+	if utf8.RuneCountInString(s) != 1 {
+		return 0, fmt.Errorf("%s must be a single character (was '%s')", name, s)
+	}
+	r, _ := utf8.DecodeRuneInString(s)
+	return r, nil
+}
+
+func isExecutionResultField(f string) bool {
+	for _, erf := range data.ExecutionResultFields {
+		if f == erf {
+			return true
+		}
+	}
+	return false
+}
+
+func hopper(in input.In, strict bool) (data.TestResults, []data.SkippedRow, error) {
+	d, err := Dialect(in)
+	if err != nil {
+		return nil, nil, err
+	}
+	if in.Project != "" {
+		if _, ok := d.Constants[data.FieldProject]; !ok {
+			c := map[string]string{data.FieldProject: in.Project}
+			for k, v := range d.Constants {
+				c[k] = v
+			}
+			d.Constants = c
+		}
+	}
+	return data.ReadTestResults(util.AbsolutePath(in.Path), d, strict)
+}
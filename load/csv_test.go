@@ -0,0 +1,29 @@
+package load
+
+import (
+	"testing"
+
+	"github.com/sealuzh/gopper/data"
+	"github.com/sealuzh/gopper/data/input"
+)
+
+func TestDialect(t *testing.T) {
+	d, err := Dialect(input.In{Delimiter: `\t`, Decimal: ",", Columns: map[string]string{data.FieldTest: "name"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if d.Comma != '\t' || d.Decimal != ',' || d.Comment != data.HopperDialect().Comment {
+		t.Errorf("unexpected dialect %+v", d)
+	}
+
+	invalid := []input.In{
+		{Delimiter: ";;"},
+		{Columns: map[string]string{"Benchmark": "name"}},
+		{Constants: map[string]string{"Commit": "c1"}},
+	}
+	for _, in := range invalid {
+		if _, err := Dialect(in); err == nil {
+			t.Errorf("expected error for %+v", in)
+		}
+	}
+}
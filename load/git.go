@@ -0,0 +1,52 @@
+package load
+
+import (
+	"bytes"
+	"fmt"
+	"os/exec"
+	"strings"
+	"time"
+
+	"github.com/sealuzh/gopper/data"
+	"github.com/sealuzh/gopper/util"
+)
+
+const (
+	gitFieldSep = "\x1f"
+	gitFormat   = "--format=%H%x1f%an%x1f%aI%x1f%s"
+)
+
+// GitLog returns the first-parent history of a branch in a local git repository, starting with the oldest commit
+func GitLog(repo, branch string) ([]data.CommitInfo, error) {
+	if branch == "" {
+		branch = "HEAD"
+	}
+	cmd := exec.Command("git", "-C", util.AbsolutePath(repo), "log", "--first-parent", gitFormat, branch, "--")
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("Could not read git history of '%s' in '%s': %v (%s)", branch, repo, err, strings.TrimSpace(stderr.String()))
+	}
+
+	lines := strings.Split(strings.TrimSpace(string(out)), "\n")
+	ret := make([]data.CommitInfo, 0, len(lines))
+	// git log starts with the newest commit
+	for i := len(lines) - 1; i >= 0; i-- {
+		fields := strings.SplitN(lines[i], gitFieldSep, 4)
+		if len(fields) != 4 {
+			continue
+		}
+		date, err := time.Parse(time.RFC3339, fields[2])
+		if err != nil {
+			return nil, fmt.Errorf("Could not parse date of commit '%s': %v", fields[0], err)
+		}
+		ret = append(ret, data.CommitInfo{
+			SHA:     fields[0],
+			Author:  fields[1],
+			Date:    date,
+			Subject: fields[3],
+		})
+	}
+	return ret, nil
+}
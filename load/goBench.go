@@ -0,0 +1,175 @@
+package load
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/sealuzh/gopper/data"
+	"github.com/sealuzh/gopper/data/input"
+	"github.com/sealuzh/gopper/util"
+)
+
+const (
+	goBenchPrefix     = "Benchmark"
+	goBenchTimeUnit   = "ns/op"
+	goBenchKeyPkg     = "pkg"
+	goBenchKeyCommit  = "commit"
+	goBenchKeyVersion = "version"
+)
+
+var goBenchConfigLine = regexp.MustCompile(`^([a-z][^\s:A-Z]*):(?:\s+(.*))?$`)
+
+// goBench imports the text output of 'go test -bench'. Every benchmark line is a repetition and results in one
+// ExecutionResult with the ns/op as value. Other metrics (e.g. B/op) are named metrics of the execution named by
+// their unit, which become separate tests '<test>:<unit>' with the "Metric" selection. Benchmark lines that can not
+// be read are skipped (see skippedRows).
+func goBench(in input.In, s *skippedRows) (data.TestResults, error) {
+	ps, err := paths(in)
+	if err != nil {
+		return nil, err
+	}
+	cm, err := newCommitMatcher(in.Commit)
+	if err != nil {
+		return nil, err
+	}
+
+	res := data.NewTestResults(data.DefaultHeading)
+	for _, p := range ps {
+		commit, _ := cm.commit(p)
+		err := goBenchFile(p, in.Project, commit, res, s)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return res, nil
+}
+
+func goBenchFile(path, project, commit string, res data.TestResults, skipped *skippedRows) error {
+	f, err := util.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	config := map[string]string{}
+	if commit != "" {
+		config[goBenchKeyCommit] = commit
+	}
+
+	s := bufio.NewScanner(f)
+	lineNr := 0
+	for s.Scan() {
+		lineNr++
+		line := strings.TrimSpace(s.Text())
+		if sm := goBenchConfigLine.FindStringSubmatch(line); sm != nil {
+			config[sm[1]] = strings.TrimSpace(sm[2])
+			continue
+		}
+		if !strings.HasPrefix(line, goBenchPrefix) {
+			continue
+		}
+
+		er, err := goBenchLine(line, project, config)
+		if err == errNoCommit {
+			return fmt.Errorf("%s:%d: %v", path, lineNr, err)
+		}
+		if err != nil {
+			if err := skipped.skip(path, lineNr, err); err != nil {
+				return err
+			}
+			continue
+		}
+		if er != nil {
+			res.Add(er)
+		}
+	}
+	return s.Err()
+}
+
+// errNoCommit is returned for benchmark lines before any commit, which is an error of the input rather than of a line
+var errNoCommit = errors.New("No commit for benchmarks")
+
+// goBenchLine returns the execution result of a benchmark line, or nil if the line is only the name of a benchmark
+// (e.g. of 'go test -v'). Without ns/op, the first metric of the line is the value.
+func goBenchLine(line, project string, config map[string]string) (*data.ExecutionResult, error) {
+	fields := strings.Fields(line)
+	if len(fields) == 1 {
+		return nil, nil
+	}
+	// name, iterations and at least one value-unit pair
+	if len(fields) < 4 || len(fields)%2 != 0 {
+		return nil, fmt.Errorf("Invalid benchmark line of '%s'", fields[0])
+	}
+	if _, err := strconv.ParseInt(fields[1], 10, 64); err != nil {
+		return nil, fmt.Errorf("Invalid iterations '%s' of benchmark '%s'", fields[1], fields[0])
+	}
+
+	commit, ok := config[goBenchKeyCommit]
+	if !ok || commit == "" {
+		return nil, errNoCommit
+	}
+
+	test := fields[0]
+	if pkg, ok := config[goBenchKeyPkg]; ok && pkg != "" {
+		test = pkg + "." + test
+	}
+
+	valueIdx := 2
+	for i := 2; i < len(fields); i += 2 {
+		if fields[i+1] == goBenchTimeUnit {
+			valueIdx = i
+			break
+		}
+	}
+
+	er := &data.ExecutionResult{
+		Project:       project,
+		Version:       config[goBenchKeyVersion],
+		SHA:           commit,
+		Configuration: goBenchConfiguration(config),
+		Test:          test,
+	}
+	for i := 2; i < len(fields); i += 2 {
+		v, err := strconv.ParseFloat(fields[i], 64)
+		if err != nil {
+			return nil, fmt.Errorf("Could not parse value '%s' of benchmark '%s': %v", fields[i], fields[0], err)
+		}
+		unit := fields[i+1]
+		if i == valueIdx {
+			er.RawVal = v
+			er.Unit = unit
+			continue
+		}
+		if er.Metrics == nil {
+			er.Metrics = make(map[string]float64)
+			er.MetricUnits = make(map[string]string)
+		}
+		er.Metrics[unit] = v
+		er.MetricUnits[unit] = unit
+	}
+	return er, nil
+}
+
+// goBenchConfiguration joins all configuration keys that do not identify the benchmark or the version
+func goBenchConfiguration(config map[string]string) string {
+	keys := make([]string, 0, len(config))
+	for k := range config {
+		switch k {
+		case goBenchKeyPkg, goBenchKeyCommit, goBenchKeyVersion:
+			continue
+		}
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	kvs := make([]string, len(keys))
+	for i, k := range keys {
+		kvs[i] = k + "=" + config[k]
+	}
+	return strings.Join(kvs, ",")
+}
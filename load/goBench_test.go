@@ -0,0 +1,191 @@
+package load
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/sealuzh/gopper/data"
+	"github.com/sealuzh/gopper/data/input"
+)
+
+const goBenchOutput = `goos: linux
+goarch: amd64
+pkg: example.com/sort
+commit: abc123
+BenchmarkSort-8     	  100000	     12000 ns/op	     512 B/op	       3 allocs/op
+BenchmarkSort-8     	  100000	     13000 ns/op	     512 B/op	       3 allocs/op
+BenchmarkMap-8      	  200000	      5000 ns/op
+PASS
+ok  	example.com/sort	3.012s
+`
+
+func writeTemp(t *testing.T, name, content string) string {
+	dir, err := ioutil.TempDir("", "gopper")
+	if err != nil {
+		t.Fatal(err)
+	}
+	p := filepath.Join(dir, name)
+	if err := ioutil.WriteFile(p, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+	return p
+}
+
+func TestGoBench(t *testing.T) {
+	p := writeTemp(t, "bench.txt", goBenchOutput)
+	defer os.RemoveAll(filepath.Dir(p))
+
+	trs, err := goBench(input.In{Path: p, Format: input.InGoBench}, &skippedRows{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name    string
+		values  []float64
+		metrics map[string][]float64
+	}{
+		{"example.com/sort.BenchmarkSort-8@goarch=amd64,goos=linux", []float64{12000, 13000}, map[string][]float64{"B/op": {512, 512}, "allocs/op": {3, 3}}},
+		{"example.com/sort.BenchmarkMap-8@goarch=amd64,goos=linux", []float64{5000}, nil},
+	}
+	if trs.Len() != len(tests) {
+		t.Fatalf("expected %d tests, got %d: %v", len(tests), trs.Len(), trs.TestNames())
+	}
+	for _, tt := range tests {
+		tr, ok := trs.Get(tt.name)
+		if !ok {
+			t.Errorf("test '%s' not imported: %v", tt.name, trs.TestNames())
+			continue
+		}
+		ers, ok := tr.ExecutionResults("abc123")
+		if !ok {
+			t.Errorf("test '%s' has no commit abc123: %v", tt.name, tr.Commits())
+			continue
+		}
+		if !equalFloats(ers.Values(), tt.values) {
+			t.Errorf("test '%s': expected values %v, got %v", tt.name, tt.values, ers.Values())
+		}
+		for i, er := range ers.All() {
+			if er.Unit != "ns/op" {
+				t.Errorf("test '%s': expected unit 'ns/op', got '%s'", tt.name, er.Unit)
+			}
+			if len(er.Metrics) != len(tt.metrics) {
+				t.Errorf("test '%s': expected metrics %v, got %v", tt.name, tt.metrics, er.Metrics)
+			}
+			for m, vs := range tt.metrics {
+				if er.Metrics[m] != vs[i] || er.MetricUnits[m] != m {
+					t.Errorf("test '%s': expected metric %s %v, got %v (%s)", tt.name, m, vs[i], er.Metrics[m], er.MetricUnits[m])
+				}
+			}
+		}
+	}
+
+	// the metrics of the former separate tests
+	allocs, err := data.SelectMetric(trs, "allocs/op")
+	if err != nil {
+		t.Fatal(err)
+	}
+	tr, ok := allocs.Get("example.com/sort.BenchmarkSort-8:allocs/op@goarch=amd64,goos=linux")
+	if !ok {
+		t.Fatalf("metric test not selected: %v", allocs.TestNames())
+	}
+	if ers, _ := tr.ExecutionResults("abc123"); ers.All()[0].Unit != "allocs/op" {
+		t.Errorf("expected unit of metric 'allocs/op', got '%s'", ers.All()[0].Unit)
+	}
+}
+
+func TestGoBenchCommitFromPath(t *testing.T) {
+	p := writeTemp(t, "bench-def456.txt", "BenchmarkA 10 100 ns/op\n")
+	defer os.RemoveAll(filepath.Dir(p))
+
+	trs, err := goBench(input.In{Path: p, Commit: `bench-(\w+)\.txt`}, &skippedRows{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	tr, ok := trs.Get("BenchmarkA")
+	if !ok {
+		t.Fatalf("test not imported: %v", trs.TestNames())
+	}
+	if cs := tr.Commits(); len(cs) != 1 || cs[0] != "def456" {
+		t.Errorf("expected commit def456, got %v", cs)
+	}
+}
+
+func TestGoBenchLine(t *testing.T) {
+	config := map[string]string{goBenchKeyCommit: "c1"}
+	tests := []struct {
+		line    string
+		result  bool
+		value   float64
+		unit    string
+		metrics int
+		err     bool
+	}{
+		{"BenchmarkA 10 100 ns/op", true, 100, "ns/op", 0, false},
+		{"BenchmarkA 10 100 ns/op 8 B/op", true, 100, "ns/op", 1, false},
+		{"BenchmarkA 10 8 B/op 100 ns/op 2 allocs/op", true, 100, "ns/op", 2, false},
+		// without ns/op, e.g. only custom metrics
+		{"BenchmarkA 10 3.5 MB/s", true, 3.5, "MB/s", 0, false},
+		// not a result line, e.g. the name of a benchmark with 'go test -v'
+		{"BenchmarkA", false, 0, "", 0, false},
+		// invalid lines, e.g. of a failing benchmark, are skipped rows
+		{"BenchmarkA --- FAIL: x y", false, 0, "", 0, true},
+		{"BenchmarkA x 100 ns/op", false, 0, "", 0, true},
+		{"BenchmarkA 10 abc ns/op", false, 0, "", 0, true},
+	}
+	for _, tt := range tests {
+		er, err := goBenchLine(tt.line, "", config)
+		if (err != nil) != tt.err {
+			t.Errorf("'%s': unexpected error %v", tt.line, err)
+		}
+		if (er != nil) != tt.result {
+			t.Errorf("'%s': expected result %v, got %v", tt.line, tt.result, er)
+			continue
+		}
+		if er == nil {
+			continue
+		}
+		if er.RawVal != tt.value || er.Unit != tt.unit || len(er.Metrics) != tt.metrics {
+			t.Errorf("'%s': expected %v %s with %d metrics, got %+v", tt.line, tt.value, tt.unit, tt.metrics, er)
+		}
+	}
+
+	if _, err := goBenchLine("BenchmarkA 10 100 ns/op", "", map[string]string{}); err == nil {
+		t.Errorf("expected error for benchmark without commit")
+	}
+}
+
+func TestGoBenchStrict(t *testing.T) {
+	p := writeTemp(t, "bench.txt", "commit: c1\nBenchmarkA\nBenchmarkA 10 100 ns/op\nBenchmarkA 10 abc ns/op\n")
+	defer os.RemoveAll(filepath.Dir(p))
+
+	// skipped rows of the data-quality report
+	trs, skipped, err := TestResults(input.In{Path: p, Format: input.InGoBench}, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if trs.Len() != 1 {
+		t.Errorf("expected a single test, got %v", trs.TestNames())
+	}
+	if len(skipped) != 1 || skipped[0].Path != p || skipped[0].Line != 4 {
+		t.Errorf("expected line 4 as skipped row, got %+v", skipped)
+	}
+
+	if _, _, err := TestResults(input.In{Path: p, Format: input.InGoBench}, true); err == nil {
+		t.Errorf("expected error for an invalid line in strict mode")
+	}
+}
+
+func equalFloats(a, b []float64) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,104 @@
+package load
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/sealuzh/gopper/data"
+	"github.com/sealuzh/gopper/data/input"
+	"github.com/sealuzh/gopper/util"
+)
+
+const (
+	gbRunTypeIteration = "iteration"
+	gbCpuTime          = "cpu_time"
+	gbContextCommit    = "commit"
+	gbContextVersion   = "version"
+)
+
+type gbReport struct {
+	Context    map[string]interface{} `json:"context"`
+	Benchmarks []gbBenchmark          `json:"benchmarks"`
+}
+
+type gbBenchmark struct {
+	Name     string  `json:"name"`
+	RunType  string  `json:"run_type"`
+	Error    bool    `json:"error_occurred"`
+	ErrorMsg string  `json:"error_message"`
+	RealTime float64 `json:"real_time"`
+	CpuTime  float64 `json:"cpu_time"`
+	TimeUnit string  `json:"time_unit"`
+}
+
+// googleBenchmark imports the JSON output of Google Benchmark (--benchmark_format=json). Every iteration run (i.e.
+// repetition) is one execution with the real time and the cpu time as named metric 'cpu_time', which becomes the separate
+// test '<test>:cpu_time' with the "Metric" selection.
+// Aggregates (e.g. mean of repetitions) are ignored, runs with an error are skipped (see skippedRows). A 'commit' in the
+// context (--benchmark_context=commit=<sha>) sets the commit.
+func googleBenchmark(in input.In, s *skippedRows) (data.TestResults, error) {
+	ps, err := paths(in)
+	if err != nil {
+		return nil, err
+	}
+	cm, err := newCommitMatcher(in.Commit)
+	if err != nil {
+		return nil, err
+	}
+
+	res := data.NewTestResults(data.DefaultHeading)
+	for _, p := range ps {
+		var r gbReport
+		err := decodeJsonFile(p, &r)
+		if err != nil {
+			return nil, err
+		}
+
+		commit, _ := cm.commit(p)
+		if c, ok := r.Context[gbContextCommit].(string); ok && c != "" {
+			commit = c
+		}
+		if commit == "" {
+			return nil, fmt.Errorf("No commit for Google Benchmark report '%s'", p)
+		}
+		version, _ := r.Context[gbContextVersion].(string)
+
+		for _, b := range r.Benchmarks {
+			if b.RunType != "" && b.RunType != gbRunTypeIteration {
+				continue
+			}
+			if b.Error {
+				err := s.skip(p, 0, fmt.Errorf("Benchmark '%s' failed: %s", b.Name, b.ErrorMsg))
+				if err != nil {
+					return nil, err
+				}
+				continue
+			}
+			res.Add(&data.ExecutionResult{
+				Project:     in.Project,
+				Version:     version,
+				SHA:         commit,
+				Test:        b.Name,
+				RawVal:      b.RealTime,
+				Unit:        b.TimeUnit,
+				Metrics:     map[string]float64{gbCpuTime: b.CpuTime},
+				MetricUnits: map[string]string{gbCpuTime: b.TimeUnit},
+			})
+		}
+	}
+	return res, nil
+}
+
+func decodeJsonFile(path string, v interface{}) error {
+	f, err := util.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	err = json.NewDecoder(f).Decode(v)
+	if err != nil {
+		return fmt.Errorf("Could not decode JSON file '%s': %v", path, err)
+	}
+	return nil
+}
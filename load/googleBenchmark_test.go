@@ -0,0 +1,88 @@
+package load
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/sealuzh/gopper/data"
+	"github.com/sealuzh/gopper/data/input"
+)
+
+const googleBenchmarkJSON = `{
+  "context": {"commit": "c1", "version": "1.0"},
+  "benchmarks": [
+    {"name": "BM_Sort/8", "run_type": "iteration", "real_time": 10.5, "cpu_time": 10.0, "time_unit": "ns"},
+    {"name": "BM_Sort/8", "run_type": "iteration", "real_time": 11.5, "cpu_time": 11.0, "time_unit": "ns"},
+    {"name": "BM_Sort/8_mean", "run_type": "aggregate", "real_time": 11.0, "cpu_time": 10.5, "time_unit": "ns"},
+    {"name": "BM_Fail", "run_type": "iteration", "error_occurred": true, "real_time": 0, "cpu_time": 0, "time_unit": "ns"}
+  ]
+}`
+
+func TestGoogleBenchmark(t *testing.T) {
+	p := writeTemp(t, "gb.json", googleBenchmarkJSON)
+	defer os.RemoveAll(filepath.Dir(p))
+
+	trs, err := googleBenchmark(input.In{Path: p, Format: input.InGoogleBenchmark}, &skippedRows{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if trs.Len() != 1 {
+		t.Fatalf("expected a single test, got %v", trs.TestNames())
+	}
+	// the cpu time is the metric of the former separate test
+	cpu, err := data.SelectMetric(trs, data.AllMetrics)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := map[string][]float64{
+		"BM_Sort/8":          {10.5, 11.5},
+		"BM_Sort/8:cpu_time": {10, 11},
+	}
+	for n, vals := range tests {
+		tr, ok := cpu.Get(n)
+		if !ok {
+			t.Errorf("test '%s' not imported: %v", n, cpu.TestNames())
+			continue
+		}
+		ers, ok := tr.ExecutionResults("c1")
+		if !ok {
+			t.Errorf("test '%s' has no commit c1", n)
+			continue
+		}
+		if !equalFloats(ers.Values(), vals) {
+			t.Errorf("test '%s': expected %v, got %v", n, vals, ers.Values())
+		}
+		if er := ers.All()[0]; er.Unit != "ns" || er.Version != "1.0" {
+			t.Errorf("test '%s': expected unit ns and version 1.0, got '%s' and '%s'", n, er.Unit, er.Version)
+		}
+	}
+}
+
+func TestGoogleBenchmarkWithoutCommit(t *testing.T) {
+	p := writeTemp(t, "gb.json", `{"benchmarks": []}`)
+	defer os.RemoveAll(filepath.Dir(p))
+
+	if _, err := googleBenchmark(input.In{Path: p}, &skippedRows{}); err == nil {
+		t.Errorf("expected error for report without commit")
+	}
+}
+
+func TestGoogleBenchmarkStrict(t *testing.T) {
+	p := writeTemp(t, "gb.json", googleBenchmarkJSON)
+	defer os.RemoveAll(filepath.Dir(p))
+
+	// the failed run is skipped, the aggregate is ignored
+	s := &skippedRows{}
+	if _, err := googleBenchmark(input.In{Path: p}, s); err != nil {
+		t.Fatal(err)
+	}
+	if len(s.rows) != 1 || s.rows[0].Path != p || !strings.Contains(s.rows[0].Reason, "BM_Fail") {
+		t.Errorf("expected BM_Fail as skipped row, got %+v", s.rows)
+	}
+
+	if _, err := googleBenchmark(input.In{Path: p}, &skippedRows{strict: true}); err == nil {
+		t.Errorf("expected error for a failed run in strict mode")
+	}
+}
@@ -0,0 +1,173 @@
+package load
+
+import (
+	"encoding/xml"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/sealuzh/gopper/data"
+	"github.com/sealuzh/gopper/data/input"
+	"github.com/sealuzh/gopper/util"
+)
+
+const (
+	jUnitExtension = ".xml"
+	jUnitUnit      = "s"
+)
+
+type jUnitSuite struct {
+	Suites []jUnitSuite `xml:"testsuite"`
+	Cases  []jUnitCase  `xml:"testcase"`
+}
+
+type jUnitCase struct {
+	ClassName string    `xml:"classname,attr"`
+	Name      string    `xml:"name,attr"`
+	Time      string    `xml:"time,attr"`
+	Skipped   *struct{} `xml:"skipped"`
+}
+
+// jUnit imports JUnit XML reports. Every path is a directory of reports of a single commit (or a single report),
+// where every report adds one execution to each of its (not skipped) test cases. Test cases with an invalid time are
+// skipped (see skippedRows).
+func jUnit(in input.In, s *skippedRows) (data.TestResults, error) {
+	ps, err := paths(in)
+	if err != nil {
+		return nil, err
+	}
+	cm, err := newCommitMatcher(in.Commit)
+	if err != nil {
+		return nil, err
+	}
+
+	res := data.NewTestResults(data.DefaultHeading)
+	for _, p := range ps {
+		isDir := false
+		if p != util.Stdin {
+			fi, err := os.Stat(p)
+			if err != nil {
+				return nil, err
+			}
+			isDir = fi.IsDir()
+		}
+
+		commit, ok := cm.commit(p)
+		if !ok {
+			if !isDir {
+				return nil, fmt.Errorf("No commit for JUnit report '%s'", p)
+			}
+			commit = filepath.Base(p)
+		}
+
+		reports := []string{p}
+		if isDir {
+			reports, err = jUnitReports(p)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		for _, r := range reports {
+			err := jUnitReport(r, in.Project, commit, res, s)
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+	return res, nil
+}
+
+// jUnitReports returns all (possibly compressed) reports in a directory
+func jUnitReports(dir string) ([]string, error) {
+	fs, err := filepath.Glob(filepath.Join(dir, "*"))
+	if err != nil {
+		return nil, err
+	}
+	ret := make([]string, 0, len(fs))
+	for _, f := range fs {
+		p, _ := util.CompressionExt(f)
+		if strings.HasSuffix(p, jUnitExtension) {
+			ret = append(ret, f)
+		}
+	}
+	return ret, nil
+}
+
+func jUnitReport(path, project, commit string, res data.TestResults, skipped *skippedRows) error {
+	f, err := util.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	var s jUnitSuite
+	err = xml.NewDecoder(f).Decode(&s)
+	if err != nil {
+		return fmt.Errorf("Could not decode JUnit report '%s': %v", path, err)
+	}
+	return jUnitAdd(s, path, project, commit, res, skipped)
+}
+
+func jUnitAdd(s jUnitSuite, path, project, commit string, res data.TestResults, skipped *skippedRows) error {
+	for _, c := range s.Cases {
+		if c.Skipped != nil {
+			continue
+		}
+		t, err := jUnitTime(c.Time)
+		if err != nil {
+			err = skipped.skip(path, 0, fmt.Errorf("Test case '%s.%s': %v", c.ClassName, c.Name, err))
+			if err != nil {
+				return err
+			}
+			continue
+		}
+		test := c.Name
+		if c.ClassName != "" {
+			test = c.ClassName + "." + c.Name
+		}
+		res.Add(&data.ExecutionResult{
+			Project: project,
+			SHA:     commit,
+			Test:    test,
+			RawVal:  t,
+			Unit:    jUnitUnit,
+		})
+	}
+	for _, ss := range s.Suites {
+		err := jUnitAdd(ss, path, project, commit, res, skipped)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// jUnitTime parses the time of a test case in seconds, which may have thousands separators (e.g. '1,234.5' of Maven
+// Surefire) or a decimal comma (e.g. '0,123' or '1,234' of a decimal comma locale). A single comma is a decimal comma.
+func jUnitTime(s string) (float64, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return 0, fmt.Errorf("No time")
+	}
+	switch {
+	case strings.Contains(s, ".") && strings.Contains(s, ","):
+		// the last separator is the decimal separator
+		if strings.LastIndex(s, ",") > strings.LastIndex(s, ".") {
+			s = strings.Replace(strings.Replace(s, ".", "", -1), ",", ".", 1)
+		} else {
+			s = strings.Replace(s, ",", "", -1)
+		}
+	case strings.Count(s, ",") > 1:
+		s = strings.Replace(s, ",", "", -1)
+	default:
+		s = strings.Replace(s, ",", ".", 1)
+	}
+	t, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return 0, fmt.Errorf("Invalid time: %v", err)
+	}
+	return t, nil
+}
@@ -0,0 +1,125 @@
+package load
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/sealuzh/gopper/data/input"
+)
+
+func TestJUnitTime(t *testing.T) {
+	tests := []struct {
+		in  string
+		out float64
+		err bool
+	}{
+		{"0.123", 0.123, false},
+		{" 12 ", 12, false},
+		{"0,123", 0.123, false},
+		{"1,5", 1.5, false},
+		// decimal comma of a locale like de_DE
+		{"1,234", 1.234, false},
+		{"1,234.5", 1234.5, false},
+		{"1.234,5", 1234.5, false},
+		{"1,234,567.25", 1234567.25, false},
+		{"", 0, true},
+		{"abc", 0, true},
+	}
+	for _, tt := range tests {
+		v, err := jUnitTime(tt.in)
+		if (err != nil) != tt.err {
+			t.Errorf("'%s': unexpected error %v", tt.in, err)
+			continue
+		}
+		if v != tt.out {
+			t.Errorf("'%s': expected %v, got %v", tt.in, tt.out, v)
+		}
+	}
+}
+
+const jUnitReportXML = `<?xml version="1.0" encoding="UTF-8"?>
+<testsuites>
+  <testsuite name="suite">
+    <testcase classname="com.example.A" name="fast" time="0,5"/>
+    <testcase classname="com.example.A" name="slow" time="1,234.5"/>
+    <testcase classname="com.example.A" name="skipped" time="0.1"><skipped/></testcase>
+    <testcase classname="com.example.A" name="noTime"/>
+  </testsuite>
+</testsuites>
+`
+
+func TestJUnit(t *testing.T) {
+	p := writeTemp(t, "report-c1.xml", jUnitReportXML)
+	defer os.RemoveAll(filepath.Dir(p))
+
+	trs, err := jUnit(input.In{Path: p, Commit: `report-(\w+)\.xml`}, &skippedRows{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := map[string]float64{
+		"com.example.A.fast": 0.5,
+		"com.example.A.slow": 1234.5,
+	}
+	if trs.Len() != len(expected) {
+		t.Fatalf("expected %d tests, got %v", len(expected), trs.TestNames())
+	}
+	for n, v := range expected {
+		tr, ok := trs.Get(n)
+		if !ok {
+			t.Errorf("test '%s' not imported", n)
+			continue
+		}
+		ers, ok := tr.ExecutionResults("c1")
+		if !ok || len(ers.Values()) != 1 || ers.Values()[0] != v {
+			t.Errorf("test '%s': expected value %v in commit c1", n, v)
+		}
+	}
+}
+
+func TestJUnitDirectory(t *testing.T) {
+	p := writeTemp(t, "a.xml", jUnitReportXML)
+	dir := filepath.Dir(p)
+	defer os.RemoveAll(dir)
+	commitDir := filepath.Join(dir, "c2")
+	if err := os.Mkdir(commitDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	for _, n := range []string{"a.xml", "b.xml"} {
+		if err := os.Link(p, filepath.Join(commitDir, n)); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	trs, err := jUnit(input.In{Path: commitDir}, &skippedRows{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	tr, ok := trs.Get("com.example.A.fast")
+	if !ok {
+		t.Fatalf("test not imported: %v", trs.TestNames())
+	}
+	ers, ok := tr.ExecutionResults("c2")
+	if !ok || len(ers.All()) != 2 {
+		t.Errorf("expected 2 executions (one per report) in commit c2 (directory name)")
+	}
+}
+
+func TestJUnitStrict(t *testing.T) {
+	p := writeTemp(t, "report-c1.xml", jUnitReportXML)
+	defer os.RemoveAll(filepath.Dir(p))
+
+	// the test case without time is skipped, the skipped test case is not executed
+	s := &skippedRows{}
+	if _, err := jUnit(input.In{Path: p, Commit: `report-(\w+)\.xml`}, s); err != nil {
+		t.Fatal(err)
+	}
+	if len(s.rows) != 1 || s.rows[0].Path != p || !strings.Contains(s.rows[0].Reason, "noTime") {
+		t.Errorf("expected test case noTime as skipped row, got %+v", s.rows)
+	}
+
+	if _, err := jUnit(input.In{Path: p, Commit: `report-(\w+)\.xml`}, &skippedRows{strict: true}); err == nil {
+		t.Errorf("expected error for a test case without time in strict mode")
+	}
+}
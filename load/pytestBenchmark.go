@@ -0,0 +1,92 @@
+package load
+
+import (
+	"fmt"
+
+	"github.com/sealuzh/gopper/data"
+	"github.com/sealuzh/gopper/data/input"
+)
+
+// pytest-benchmark reports all statistics in seconds
+const pytestBenchmarkUnit = "s"
+
+type pbReport struct {
+	CommitInfo struct {
+		Id string `json:"id"`
+	} `json:"commit_info"`
+	Benchmarks []pbBenchmark `json:"benchmarks"`
+}
+
+type pbBenchmark struct {
+	Name     string `json:"name"`
+	FullName string `json:"fullname"`
+	Stats    struct {
+		Mean *float64  `json:"mean"`
+		Data []float64 `json:"data"`
+	} `json:"stats"`
+}
+
+// pytestBenchmark imports the JSON output of pytest-benchmark (--benchmark-json). Every raw sample of 'data'
+// (--benchmark-save-data) is one execution; without raw data, the mean is the only execution. Benchmarks without name
+// or values are skipped (see skippedRows).
+func pytestBenchmark(in input.In, s *skippedRows) (data.TestResults, error) {
+	ps, err := paths(in)
+	if err != nil {
+		return nil, err
+	}
+	cm, err := newCommitMatcher(in.Commit)
+	if err != nil {
+		return nil, err
+	}
+
+	res := data.NewTestResults(data.DefaultHeading)
+	for _, p := range ps {
+		var r pbReport
+		err := decodeJsonFile(p, &r)
+		if err != nil {
+			return nil, err
+		}
+
+		commit, _ := cm.commit(p)
+		if r.CommitInfo.Id != "" {
+			commit = r.CommitInfo.Id
+		}
+		if commit == "" {
+			return nil, fmt.Errorf("No commit for pytest-benchmark report '%s'", p)
+		}
+
+		for _, b := range r.Benchmarks {
+			test := b.FullName
+			if test == "" {
+				test = b.Name
+			}
+			vals := b.Stats.Data
+			if len(vals) == 0 && b.Stats.Mean != nil {
+				vals = []float64{*b.Stats.Mean}
+			}
+			var reason error
+			switch {
+			case test == "":
+				reason = fmt.Errorf("Benchmark without name")
+			case len(vals) == 0:
+				reason = fmt.Errorf("Benchmark '%s' without data or mean", test)
+			}
+			if reason != nil {
+				if err := s.skip(p, 0, reason); err != nil {
+					return nil, err
+				}
+				continue
+			}
+			for _, v := range vals {
+				res.Add(&data.ExecutionResult{
+					Project: in.Project,
+					SHA:     commit,
+					Test:    test,
+					RawVal:  v,
+					Unit:    pytestBenchmarkUnit,
+				})
+			}
+		}
+	}
+	return res, nil
+}
@@ -0,0 +1,86 @@
+package load
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/sealuzh/gopper/data/input"
+)
+
+const pytestBenchmarkJSON = `{
+  "commit_info": {"id": "c1"},
+  "benchmarks": [
+    {"name": "test_sort", "fullname": "tests/test_sort.py::test_sort", "stats": {"mean": 0.2, "data": [0.1, 0.2, 0.3]}},
+    {"name": "test_map", "stats": {"mean": 0.5}}
+  ]
+}`
+
+func TestPytestBenchmark(t *testing.T) {
+	p := writeTemp(t, "pb.json", pytestBenchmarkJSON)
+	defer os.RemoveAll(filepath.Dir(p))
+
+	trs, err := pytestBenchmark(input.In{Path: p, Format: input.InPytestBenchmark}, &skippedRows{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := map[string][]float64{
+		// raw data are the executions
+		"tests/test_sort.py::test_sort": {0.1, 0.2, 0.3},
+		// without raw data, the mean is the execution
+		"test_map": {0.5},
+	}
+	if trs.Len() != len(tests) {
+		t.Fatalf("expected %d tests, got %v", len(tests), trs.TestNames())
+	}
+	for n, vals := range tests {
+		tr, ok := trs.Get(n)
+		if !ok {
+			t.Errorf("test '%s' not imported", n)
+			continue
+		}
+		ers, ok := tr.ExecutionResults("c1")
+		if !ok || !equalFloats(ers.Values(), vals) {
+			t.Errorf("test '%s': expected %v in commit c1", n, vals)
+		}
+	}
+}
+
+func TestPytestBenchmarkCommitFromPath(t *testing.T) {
+	p := writeTemp(t, "0001_c2.json", `{"benchmarks": [{"name": "test_a", "stats": {"mean": 1}}]}`)
+	defer os.RemoveAll(filepath.Dir(p))
+
+	trs, err := pytestBenchmark(input.In{Path: p, Commit: `_(\w+)\.json`}, &skippedRows{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	tr, ok := trs.Get("test_a")
+	if !ok {
+		t.Fatalf("test not imported: %v", trs.TestNames())
+	}
+	if _, ok := tr.ExecutionResults("c2"); !ok {
+		t.Errorf("expected commit c2, got %v", tr.Commits())
+	}
+}
+
+func TestPytestBenchmarkStrict(t *testing.T) {
+	p := writeTemp(t, "pb.json", `{"commit_info": {"id": "c1"}, "benchmarks": [{"name": "test_a", "stats": {"mean": 1}}, {"name": "test_b", "stats": {}}]}`)
+	defer os.RemoveAll(filepath.Dir(p))
+
+	s := &skippedRows{}
+	trs, err := pytestBenchmark(input.In{Path: p}, s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if trs.Len() != 1 {
+		t.Errorf("expected a single test, got %v", trs.TestNames())
+	}
+	if len(s.rows) != 1 || s.rows[0].Path != p || !strings.Contains(s.rows[0].Reason, "test_b") {
+		t.Errorf("expected test_b as skipped row, got %+v", s.rows)
+	}
+
+	if _, err := pytestBenchmark(input.In{Path: p}, &skippedRows{strict: true}); err == nil {
+		t.Errorf("expected error for a benchmark without values in strict mode")
+	}
+}
@@ -0,0 +1,24 @@
+package load
+
+import (
+	"github.com/sealuzh/gopper/data"
+	"github.com/sealuzh/gopper/data/input"
+	"github.com/sealuzh/gopper/store"
+	"github.com/sealuzh/gopper/util"
+)
+
+// sqlite loads the test results of a SQLite store, restricted by project, tests and commit range of the input
+func sqlite(in input.In) (data.TestResults, error) {
+	s, err := store.OpenSQLite(util.AbsolutePath(in.Path))
+	if err != nil {
+		return nil, err
+	}
+	defer s.Close()
+
+	return s.TestResults(store.Query{
+		Project: in.Project,
+		Tests:   in.Tests,
+		From:    in.From,
+		To:      in.To,
+	})
+}
@@ -0,0 +1,121 @@
+package load
+
+import (
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"sort"
+
+	"github.com/sealuzh/gopper/data"
+	"github.com/sealuzh/gopper/data/input"
+	"github.com/sealuzh/gopper/util"
+)
+
+// TestResults reads an input with the importer of its format and returns the rows that could not be read.
+// In strict mode, invalid rows are an error.
+func TestResults(in input.In, strict bool) (data.TestResults, []data.SkippedRow, error) {
+	var trs data.TestResults
+	var skipped []data.SkippedRow
+	var err error
+	if in.Format == "" || in.Format == input.InHopper {
+		trs, skipped, err = hopper(in, strict)
+	} else {
+		s := &skippedRows{strict: strict}
+		trs, err = importer(in, s)
+		skipped = s.rows
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+	for _, tn := range trs.TestNames() {
+		tr, ok := trs.Get(tn)
+		if !ok {
+			continue
+		}
+		setUnit(tr, in.Unit, in.HigherIsBetter)
+	}
+	return trs, skipped, nil
+}
+
+func importer(in input.In, s *skippedRows) (data.TestResults, error) {
+	switch in.Format {
+	case input.InGoBench:
+		return goBench(in, s)
+	case input.InJUnit:
+		return jUnit(in, s)
+	case input.InGoogleBenchmark:
+		return googleBenchmark(in, s)
+	case input.InPytestBenchmark:
+		return pytestBenchmark(in, s)
+	case input.InSQLite:
+		return sqlite(in)
+	default:
+		return nil, fmt.Errorf("Unknown input format '%s'", in.Format)
+	}
+}
+
+// skippedRows collects the rows of an input that an importer can not read. In strict mode, such a row is an error.
+type skippedRows struct {
+	strict bool
+	rows   []data.SkippedRow
+}
+
+// skip records a row of path that can not be read (line 0 if the format has no lines), or returns it as error in
+// strict mode
+func (s *skippedRows) skip(path string, line int, reason error) error {
+	if s.strict {
+		if line > 0 {
+			return fmt.Errorf("%s:%d: %v", path, line, reason)
+		}
+		return fmt.Errorf("%s: %v", path, reason)
+	}
+	s.rows = append(s.rows, data.SkippedRow{Path: path, Line: line, Reason: reason.Error()})
+	return nil
+}
+
+// paths returns all files or directories that match the (glob) path of an input in lexical order, or the standard input
+func paths(in input.In) ([]string, error) {
+	p := util.AbsolutePath(in.Path)
+	if p == util.Stdin {
+		return []string{p}, nil
+	}
+	ps, err := filepath.Glob(p)
+	if err != nil {
+		return nil, err
+	}
+	if len(ps) == 0 {
+		return nil, fmt.Errorf("No file matches path '%s'", p)
+	}
+	sort.Strings(ps)
+	return ps, nil
+}
+
+type commitMatcher struct {
+	re *regexp.Regexp
+}
+
+func newCommitMatcher(pattern string) (*commitMatcher, error) {
+	if pattern == "" {
+		return &commitMatcher{}, nil
+	}
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, fmt.Errorf("Invalid commit pattern '%s': %v", pattern, err)
+	}
+	if re.NumSubexp() < 1 {
+		return nil, fmt.Errorf("Commit pattern '%s' has no sub-expression", pattern)
+	}
+	return &commitMatcher{re: re}, nil
+}
+
+// commit extracts the commit from the base name of a path with the first sub-expression of the pattern
+func (m *commitMatcher) commit(path string) (string, bool) {
+	if m.re == nil {
+		return "", false
+	}
+	sm := m.re.FindStringSubmatch(filepath.Base(path))
+	if sm == nil || sm[1] == "" {
+		return "", false
+	}
+	return sm[1], true
+}
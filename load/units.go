@@ -0,0 +1,55 @@
+package load
+
+import (
+	"fmt"
+	"regexp"
+
+	"github.com/sealuzh/gopper/data"
+	"github.com/sealuzh/gopper/data/input"
+)
+
+// Units sets the units and directions of the tests matching the configured units. Later units take precedence.
+func Units(trs data.TestResults, units []input.Unit) error {
+	for _, u := range units {
+		re, err := regexp.Compile(u.Tests)
+		if err != nil {
+			return fmt.Errorf("Invalid test pattern '%s' of unit: %v", u.Tests, err)
+		}
+		for _, tn := range trs.TestNames() {
+			if !re.MatchString(tn) {
+				continue
+			}
+			tr, ok := trs.Get(tn)
+			if !ok {
+				continue
+			}
+			setUnit(tr, u.Unit, u.HigherIsBetter)
+		}
+	}
+	return nil
+}
+
+// setUnit overrides the unit of a test if a unit name is given, and its direction if higherIsBetter is set. The unit
+// name is also set on the executions, hence it is saved with them and kept when tests are merged.
+func setUnit(tr data.TestResult, name string, higherIsBetter *bool) {
+	if name == "" && higherIsBetter == nil {
+		return
+	}
+	u := tr.Unit()
+	if name != "" {
+		u = data.NewUnit(name)
+		for _, c := range tr.Commits() {
+			ers, ok := tr.ExecutionResults(c)
+			if !ok {
+				continue
+			}
+			for _, er := range ers.All() {
+				er.Unit = name
+			}
+		}
+	}
+	if higherIsBetter != nil {
+		u.HigherIsBetter = *higherIsBetter
+	}
+	tr.SetUnit(u)
+}
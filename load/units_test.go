@@ -0,0 +1,62 @@
+package load
+
+import (
+	"testing"
+
+	"github.com/sealuzh/gopper/data"
+	"github.com/sealuzh/gopper/data/input"
+)
+
+func TestUnits(t *testing.T) {
+	trs := data.NewTestResults(data.DefaultHeading)
+	for _, test := range []string{"ThroughputBench", "LatencyBench"} {
+		for _, c := range []string{"c1", "c2"} {
+			err := trs.Add(&data.ExecutionResult{Project: "p", Version: c, SHA: c, Configuration: "default", Test: test, RawVal: 1, Unit: "ns/op"})
+			if err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+
+	lower := false
+	err := Units(trs, []input.Unit{
+		{Tests: "Throughput", Unit: "ops/s"},
+		{Tests: "Latency", HigherIsBetter: &lower},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tp, _ := trs.Get("p/ThroughputBench@default")
+	if u := tp.Unit(); u.Name != "ops/s" || !u.HigherIsBetter {
+		t.Errorf("Unit of ThroughputBench = %+v", u)
+	}
+	for _, c := range tp.Commits() {
+		ers, _ := tp.ExecutionResults(c)
+		for _, er := range ers.All() {
+			if er.Unit != "ops/s" {
+				t.Errorf("Unit of execution in %s = %q", c, er.Unit)
+			}
+		}
+	}
+
+	lb, _ := trs.Get("p/LatencyBench@default")
+	if u := lb.Unit(); u.Name != "ns/op" || u.HigherIsBetter {
+		t.Errorf("Unit of LatencyBench = %+v", u)
+	}
+
+	// merged tests keep the unit of the executions
+	merged := data.NewTestResults(data.DefaultHeading)
+	merged.AddTest(data.NewTestResult("p", "ThroughputBench", "default"))
+	if err := merged.AddTest(tp); err != nil {
+		t.Fatal(err)
+	}
+	m, _ := merged.Get("p/ThroughputBench@default")
+	if u := m.Unit(); u.Name != "ops/s" {
+		t.Errorf("Unit of merged test = %+v", u)
+	}
+
+	if err := Units(trs, []input.Unit{{Tests: "(", Unit: "s"}}); err == nil {
+		t.Errorf("Expected an error for an invalid pattern")
+	}
+}
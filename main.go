@@ -13,9 +13,15 @@ import (
 	"github.com/sealuzh/gopper/analyse"
 	"github.com/sealuzh/gopper/data"
 	"github.com/sealuzh/gopper/data/input"
+	"github.com/sealuzh/gopper/load"
 	"github.com/sealuzh/gopper/save"
+	"github.com/sealuzh/gopper/transform/aggregate"
 	"github.com/sealuzh/gopper/transform/changepoints"
 	"github.com/sealuzh/gopper/transform/filter"
+	"github.com/sealuzh/gopper/transform/normalise"
+	"github.com/sealuzh/gopper/transform/order"
+	"github.com/sealuzh/gopper/transform/sample"
+	"github.com/sealuzh/gopper/transform/slice"
 	"github.com/sealuzh/gopper/transform/testresults"
 	"github.com/sealuzh/gopper/util"
 	"github.com/sealuzh/gopper/validate"
@@ -35,18 +41,55 @@ func main() {
 
 	var outTr []data.TestResults
 	var outCp []data.ChangePoints
+	// filter decisions per test results
+	var outReports []*data.FilterReport
 
 	// read in data
 	var ins []data.TestResults = make([]data.TestResults, len(config.In))
+	var qs []data.Quality
 	for i, in := range config.In {
-		in := util.AbsolutePath(in)
-		r, err := data.TestResultsFromFile(in)
+		r, skipped, err := load.TestResults(in, config.Strict)
 		if err != nil {
-			fmt.Printf("ERROR - could not read/parse file '%s': %v\n", in, err)
+			fmt.Printf("ERROR - could not read/parse file '%s': %v\n", in.Path, err)
+			return
+		}
+		if len(skipped) > 0 {
+			fmt.Printf("WARN - Skipped %d invalid rows of '%s'\n", len(skipped), in.Path)
+		}
+		if config.Quality.Path != "" {
+			qs = append(qs, data.NewQuality(in.Path, r, skipped, config.Quality.MinExecutions))
+		}
+		if config.Metric != "" {
+			r, err = data.SelectMetric(r, config.Metric)
+			if err != nil {
+				fmt.Printf("ERROR - could not select metric of '%s': %v\n", in.Path, err)
+				return
+			}
+		}
+		err = load.Units(r, config.Units)
+		if err != nil {
+			fmt.Printf("ERROR - could not set units: %v\n", err)
 			return
 		}
 		ins[i] = r
 	}
+	if config.Quality.Path != "" {
+		save.Quality(config.Quality.Path, qs)
+	}
+	if len(config.Configurations.Include) > 0 || len(config.Configurations.Exclude) > 0 {
+		fmt.Printf("# Select configurations\n")
+		cf := filter.Configurations(config.Configurations.Include, config.Configurations.Exclude)
+		for i, in := range ins {
+			ins[i] = data.Transform(ctx, in, cf)
+		}
+	}
+	infos := commitInfosFromIn(config)
+	orderFunc := orderFuncFromIn(config, infos)
+	ins = orderCommits(ctx, ins, orderFunc)
+	if config.Gaps.Name != "" {
+		fmt.Printf("# Align tests onto version sequence\n")
+		printGaps(ins)
+	}
 
 	// execute sub-programs
 	outTr = ins
@@ -59,21 +102,33 @@ func main() {
 		// sequentially compute stages
 		switch sp {
 		case input.SpSave:
-			handleSave(ctx, i, outTr, outCp, config)
+			handleSave(ctx, i, outTr, outCp, outReports, infos, config)
 		case input.SpPlot:
-			handlePlot(ctx, i, outTr, config)
+			handlePlot(ctx, i, outTr, infos, config)
 		case input.SpTRsToCPs:
 			outCp = handleTRsToCPs(ctx, i, outTr, config)
 		case input.SpMerge:
-			outTr = []data.TestResults{data.Merge(ctx, outTr)}
+			outTr = orderCommits(ctx, []data.TestResults{handleMerge(ctx, outTr, config)}, orderFunc)
+			if config.Gaps.Name != "" {
+				printGaps(outTr)
+			}
+			if outReports != nil {
+				outReports = []*data.FilterReport{mergeFilterReports(outReports)}
+			}
 		case input.SpRmDupTns:
 			outCp = handleRmDupTns(ctx, i, outCp)
 		case input.SpAnalyse:
 			// only supports a single analyse function
-			anFunc := analysisFuncFromIn(config)
-			outTr = siso(ctx, sp, outTr, config, anFunc)
+			anFuncs := analysisFuncsFromIn(config)
+			outTr = siso(ctx, sp, outTr, config, anFuncs, nil)
 		case input.SpFilter:
-			outTr = siso(ctx, sp, outTr, config, nil)
+			if len(outReports) != len(outTr) {
+				outReports = make([]*data.FilterReport, len(outTr))
+				for j := range outReports {
+					outReports[j] = data.NewFilterReport()
+				}
+			}
+			outTr = siso(ctx, sp, outTr, config, analysisFuncs{}, outReports)
 		default:
 			panic(fmt.Sprintf("ERROR - Unknown Sub-Program '%v'\n", sp))
 		}
@@ -82,12 +137,135 @@ func main() {
 	fmt.Printf("# Total execution time: %v\n", time.Since(startTime))
 }
 
-func handleSave(ctx context.Context, stageNr int, trs []data.TestResults, cps []data.ChangePoints, config input.Config) {
+func handleMerge(ctx context.Context, trs []data.TestResults, config input.Config) data.TestResults {
+	merged, summary, err := data.Merge(ctx, trs, mergeStrategyFromIn(config))
+	if err != nil {
+		panic(fmt.Sprintf("ERROR - Could not merge test results: %v", err))
+	}
+	fmt.Printf("  Merged %s\n", summary)
+	return merged
+}
+
+func mergeStrategyFromIn(config input.Config) data.MergeStrategy {
+	switch config.Merge.Name {
+	case "", input.MergeAppend:
+		return data.MergeAppend
+	case input.MergeDedup:
+		return data.MergeDedup
+	case input.MergeFirst:
+		return data.MergeFirst
+	case input.MergeLast:
+		return data.MergeLast
+	case input.MergeError:
+		return data.MergeError
+	default:
+		panic(fmt.Sprintf("ERROR - Unknown merge strategy '%s'", config.Merge.Name))
+	}
+}
+
+// commitInfosFromIn returns the history of the git repository, or nil if no repository is provided
+func commitInfosFromIn(config input.Config) []data.CommitInfo {
+	if config.Git.Path == "" {
+		return nil
+	}
+	infos, err := load.GitLog(config.Git.Path, config.Git.Branch)
+	if err != nil {
+		panic(err)
+	}
+	return infos
+}
+
+// orderFuncFromIn returns the version ordering strategy, which is git if a repository is provided and insertion otherwise
+func orderFuncFromIn(config input.Config, infos []data.CommitInfo) order.Strategy {
+	s := orderStrategyFromIn(config, infos)
+	if config.Gaps.Name == "" {
+		return s
+	}
+	// missing versions of tests are gaps in the version sequence of all tests, in the order of s
+	return order.Chain(s, order.Gaps(s))
+}
+
+func orderStrategyFromIn(config input.Config, infos []data.CommitInfo) order.Strategy {
+	name := config.Order.Name
+	if name == "" {
+		name = input.OrderInsertion
+		if config.Git.Path != "" {
+			name = input.OrderGit
+		}
+	}
+
+	switch name {
+	case input.OrderInsertion:
+		return order.Insertion()
+	case input.OrderSemVer:
+		return order.SemVer()
+	case input.OrderTimestamp:
+		var layout string
+		if len(config.Order.Params) > 0 {
+			l, err := input.StringParam(config.Order, 0)
+			if err != nil {
+				panic(err)
+			}
+			layout = l
+		}
+		return order.Timestamp(layout)
+	case input.OrderList:
+		path, err := input.StringParam(config.Order, 0)
+		if err != nil {
+			panic(err)
+		}
+		commits, err := load.CommitList(path)
+		if err != nil {
+			panic(err)
+		}
+		return order.Commits(commits)
+	case input.OrderGit:
+		return order.Git(infos)
+	default:
+		// should not happen, validity of function already checked by validate.Order
+		panic(fmt.Sprintf("Invalid order function name '%s'", name))
+	}
+}
+
+// printGaps prints the number of tests with missing versions of every test results
+func printGaps(trs []data.TestResults) {
+	for _, tr := range trs {
+		tests, missing := 0, 0
+		for t := range tr.All() {
+			gaps := t.Gaps()
+			if len(gaps) > 0 {
+				tests++
+			}
+			for _, g := range gaps {
+				missing += len(g.Missing)
+			}
+		}
+		fmt.Printf("  %d/%d tests with gaps, %d missing versions in %d versions\n", tests, tr.Len(), missing, len(data.Commits(tr)))
+	}
+}
+
+func orderCommits(ctx context.Context, trs []data.TestResults, f order.Strategy) []data.TestResults {
+	ret := make([]data.TestResults, len(trs))
+	for i, tr := range trs {
+		otr, err := f(ctx, tr)
+		if err != nil {
+			fmt.Printf("ERROR - Could not order the commits, the input order is kept: %v\n", err)
+			otr = tr
+		}
+		ret[i] = otr
+	}
+	return ret
+}
+
+func handleSave(ctx context.Context, stageNr int, trs []data.TestResults, cps []data.ChangePoints, reports []*data.FilterReport, infos []data.CommitInfo, config input.Config) {
 	if cps != nil {
-		save.ChangePoints(stageNr, trs, cps, config.Out.ChangePoints)
+		save.ChangePoints(stageNr, trs, cps, config.Out.ChangePoints, data.NewCommitInfos(infos))
 	}
 	if trs != nil {
-		save.TestResults(stageNr, trs, config.Out.TestResults)
+		save.TestResults(stageNr, trs, config.Out.TestResults, dialectsFromIn(trs, config))
+		if reports != nil {
+			save.FilterReports(reports, config.Out.TestResults, config.Out.FilterReport)
+		}
 	}
 	if trs == nil && cps == nil {
 		// save provided but no results available
@@ -95,6 +273,38 @@ func handleSave(ctx context.Context, stageNr int, trs []data.TestResults, cps []
 	}
 }
 
+// mergeFilterReports returns a report with the filter decisions of all reports
+func mergeFilterReports(reports []*data.FilterReport) *data.FilterReport {
+	ret := data.NewFilterReport()
+	for _, r := range reports {
+		for _, d := range r.Decisions() {
+			ret.Add(d)
+		}
+	}
+	return ret
+}
+
+// dialectsFromIn returns the CSV dialects of the inputs, if the output keeps the dialect of the input
+func dialectsFromIn(trs []data.TestResults, config input.Config) []data.CSVDialect {
+	if !config.Out.KeepDialect {
+		return nil
+	}
+	ds := make([]data.CSVDialect, len(trs))
+	for i := range trs {
+		// merged test results keep the dialect of the first input
+		in := config.In[0]
+		if len(trs) == len(config.In) {
+			in = config.In[i]
+		}
+		d, err := load.Dialect(in)
+		if err != nil {
+			panic(err)
+		}
+		ds[i] = d
+	}
+	return ds
+}
+
 func handleRmDupTns(ctx context.Context, stageNr int, cps []data.ChangePoints) []data.ChangePoints {
 	cps, err := changepoints.RemoveDuplicateTestNames(ctx, cps)
 	if err != nil {
@@ -115,24 +325,61 @@ func handleTRsToCPs(ctx context.Context, stageNr int, trs []data.TestResults, co
 	return cps
 }
 
-func handlePlot(ctx context.Context, stageNr int, trs []data.TestResults, config input.Config) {
+func handlePlot(ctx context.Context, stageNr int, trs []data.TestResults, infos []data.CommitInfo, config input.Config) {
+	ci := data.NewCommitInfos(infos)
 	for _, tr := range trs {
-		save.Plots(ctx, tr, fmt.Sprintf("%s%d", util.AbsolutePath(config.Out.Plot), stageNr))
+		save.Plots(ctx, tr, fmt.Sprintf("%s%d", util.AbsolutePath(config.Out.Plot), stageNr), ci)
 	}
 }
 
-func siso(ctx context.Context, sp string, ins []data.TestResults, in input.Config, af data.AnalysisFunc) []data.TestResults {
+// indexedTestResults are the test results of the input with index i
+type indexedTestResults struct {
+	i   int
+	trs data.TestResults
+}
+
+// siso executes the sub-program sp on every input. The filter decisions of sp filter are added to the report of
+// the input (reports may be nil).
+func siso(ctx context.Context, sp string, ins []data.TestResults, in input.Config, afs analysisFuncs, reports []*data.FilterReport) []data.TestResults {
 	l := len(ins)
-	c := make(chan data.TestResults)
+	c := make(chan indexedTestResults)
 	done := make(chan struct{})
-	for _, v := range ins {
-		v := v
+	for i, v := range ins {
+		i, v := i, v
 		go func() {
 			switch sp {
 			case input.SpFilter:
-				c <- data.Transform(ctx, v, transFuncsFromIn(in)...)
+				var report *data.FilterReport
+				if reports != nil {
+					report = reports[i]
+				}
+				c <- indexedTestResults{i, byProject(v, in, func(p string, trs data.TestResults) data.TestResults {
+					tfIns := projectConfig(in, p).Transform
+					tfs, ms, err := transFuncsFromIn(tfIns, data.Commits(trs))
+					if err != nil {
+						// e.g. a commit range that is not in the versions of the project
+						if p != "" {
+							err = fmt.Errorf("Project '%s': %v", p, err)
+						}
+						fmt.Printf("ERROR - All tests are filtered: %v\n", err)
+						tfs = []data.TransFunc{filter.None(err.Error())}
+						tfIns = []input.Func{{Name: "transform"}}
+						ms = nil
+					}
+					ts := make([]data.Transformation, len(tfs))
+					for j, tf := range tfs {
+						ts[j] = data.Transformation{Name: transName(tfIns[j]), Func: tf}
+					}
+					r := data.TransformReport(ctx, trs, ts, report)
+					for _, m := range ms {
+						fmt.Printf("  Matches of %s\n", m)
+					}
+					return r
+				})}
 			case input.SpAnalyse:
-				c <- data.Analyse(ctx, v, af)
+				c <- indexedTestResults{i, byProject(v, in, func(p string, trs data.TestResults) data.TestResults {
+					return data.Analyse(ctx, trs, afs.get(p))
+				})}
 			default:
 				fmt.Printf("ERROR - Unknown Sub-Program '%v'\n", sp)
 			}
@@ -158,19 +405,108 @@ func siso(ctx context.Context, sp string, ins []data.TestResults, in input.Confi
 		close(done)
 	}()
 
-	res := make([]data.TestResults, 0, l)
+	// results in the order of the inputs
+	ires := make([]data.TestResults, l)
 	for r := range c {
-		res = append(res, r)
+		ires[r.i] = r.trs
+	}
+	res := make([]data.TestResults, 0, l)
+	for _, r := range ires {
+		if r != nil {
+			res = append(res, r)
+		}
 	}
 	return res
 }
 
-func analysisFuncFromIn(in input.Config) data.AnalysisFunc {
+// byProject applies f to the tests of every project separately, if projects have their own configuration
+func byProject(in data.TestResults, config input.Config, f func(project string, trs data.TestResults) data.TestResults) data.TestResults {
+	if len(config.Projects) == 0 {
+		return f("", in)
+	}
+
+	projects, trs := data.ByProject(in)
+	ret := data.NewTestResults(in.Heading())
+	for _, p := range projects {
+		r := f(p, trs[p])
+		for _, n := range r.TestNames() {
+			tr, ok := r.Get(n)
+			if !ok {
+				panic(fmt.Sprintf("TestNames and Get inconsistent for name '%s'", n))
+			}
+			ret.AddTest(tr)
+		}
+	}
+	return ret
+}
+
+// projectConfig returns the config with the transformations and analysis of a project, if configured
+func projectConfig(config input.Config, project string) input.Config {
+	pc, ok := config.Projects[project]
+	if !ok {
+		return config
+	}
+	if len(pc.Transform) > 0 {
+		config.Transform = pc.Transform
+	}
+	if pc.Analyse.Name != "" {
+		config.Analyse = pc.Analyse
+	}
+	return config
+}
+
+type analysisFuncs struct {
+	def      data.AnalysisFunc
+	projects map[string]data.AnalysisFunc
+	// gap handling, if configured
+	gaps   bool
+	policy data.GapPolicy
+	maxGap int
+}
+
+// get returns the analysis function of a project
+func (a analysisFuncs) get(project string) data.AnalysisFunc {
+	f := a.def
+	if pf, ok := a.projects[project]; ok {
+		f = pf
+	}
+	if a.gaps {
+		f = data.AnalyseGaps(f, a.policy, a.maxGap)
+	}
+	return f
+}
+
+func analysisFuncsFromIn(in input.Config) analysisFuncs {
+	afs := analysisFuncs{
+		def:      analysisFuncFromIn(in.Analyse),
+		projects: make(map[string]data.AnalysisFunc),
+	}
+	switch in.Gaps.Name {
+	case input.GapsSkip:
+		afs.gaps, afs.policy = true, data.GapSkip
+	case input.GapsBreak:
+		afs.gaps, afs.policy = true, data.GapBreak
+	case input.GapsMax:
+		n, err := input.IntParam(in.Gaps, 0)
+		if err != nil {
+			panic(err)
+		}
+		afs.gaps, afs.policy, afs.maxGap = true, data.GapMax, n
+	}
+	for p, pc := range in.Projects {
+		if pc.Analyse.Name != "" {
+			afs.projects[p] = analysisFuncFromIn(pc.Analyse)
+		}
+	}
+	return afs
+}
+
+func analysisFuncFromIn(an input.Func) data.AnalysisFunc {
 	var f data.AnalysisFunc
-	funcName := in.Analyse.Name
+	funcName := an.Name
 	switch funcName {
 	case input.AnalyseBcp:
-		probability, err := input.Float64Param(in.Analyse, 0)
+		probability, err := input.Float64Param(an, 0)
 		if err != nil {
 			panic(err)
 		}
@@ -180,7 +516,7 @@ func analysisFuncFromIn(in input.Config) data.AnalysisFunc {
 		}
 		f = fn
 	case input.AnalyseTwitter:
-		minMean, err := input.IntParam(in.Analyse, 0)
+		minMean, err := input.IntParam(an, 0)
 		if err != nil {
 			panic(err)
 		}
@@ -190,11 +526,11 @@ func analysisFuncFromIn(in input.Config) data.AnalysisFunc {
 		}
 		f = fn
 	case input.AnalyseTtest:
-		sig, err := input.Float64Param(in.Analyse, 0)
+		sig, err := input.Float64Param(an, 0)
 		if err != nil {
 			panic(err)
 		}
-		paired, err := input.BoolParam(in.Analyse, 1)
+		paired, err := input.BoolParam(an, 1)
 		if err != nil {
 			panic(err)
 		}
@@ -210,31 +546,236 @@ func analysisFuncFromIn(in input.Config) data.AnalysisFunc {
 	return f
 }
 
-func transFuncsFromIn(in input.Config) []data.TransFunc {
-	fs := make([]data.TransFunc, 0, len(in.Transform))
-	for _, f := range in.Transform {
+// transName returns the name of a transformation in filter reports
+func transName(f input.Func) string {
+	if len(f.Params) == 0 {
+		return f.Name
+	}
+	return fmt.Sprintf("%s %v", f.Name, f.Params)
+}
+
+// transFuncsFromIn returns the transformations of the config for tests with the commit sequence seq and the pattern
+// matches of its name filters. An error is returned if the transformations are invalid (see validate.Transformators) or
+// cannot be resolved for seq.
+func transFuncsFromIn(tfs []input.Func, seq []string) ([]data.TransFunc, []*filter.Matches, error) {
+	fs := make([]data.TransFunc, 0, len(tfs))
+	var ms []*filter.Matches
+	for _, f := range tfs {
 		switch f.Name {
 		case input.FilterMinMean:
 			v, err := input.Float64Param(f, 0)
 			if err != nil {
-				panic(err)
+				return nil, nil, fmt.Errorf("%s: %v", f.Name, err)
 			}
 			fs = append(fs, filter.MinMeanRuntime(v))
 		case input.FilterMinMedian:
 			v, err := input.Float64Param(f, 0)
 			if err != nil {
-				panic(err)
+				return nil, nil, fmt.Errorf("%s: %v", f.Name, err)
 			}
 			fs = append(fs, filter.MinMedianRuntime(v))
 		case input.FilterMinVersions:
 			v, err := input.IntParam(f, 0)
 			if err != nil {
-				panic(err)
+				return nil, nil, fmt.Errorf("%s: %v", f.Name, err)
 			}
 			fs = append(fs, filter.MinVersions(v))
+		case input.FilterBaseName:
+			ns, err := input.StringParams(f, 0)
+			if err != nil {
+				return nil, nil, fmt.Errorf("%s: %v", f.Name, err)
+			}
+			fs = append(fs, filter.BaseNames(ns))
+		case input.FilterParameter:
+			key, err := input.StringParam(f, 0)
+			if err != nil {
+				return nil, nil, fmt.Errorf("%s: %v", f.Name, err)
+			}
+			vs, err := input.StringParams(f, 1)
+			if err != nil {
+				return nil, nil, fmt.Errorf("%s: %v", f.Name, err)
+			}
+			fs = append(fs, filter.Parameter(key, vs))
+		case input.FilterInclude, input.FilterExclude, input.FilterIncludeProj, input.FilterExcludeProj:
+			ps, err := input.StringParams(f, 0)
+			if err != nil {
+				return nil, nil, fmt.Errorf("%s: %v", f.Name, err)
+			}
+			include := f.Name == input.FilterInclude || f.Name == input.FilterIncludeProj
+			names := filter.Tests
+			if f.Name == input.FilterIncludeProj || f.Name == input.FilterExcludeProj {
+				names = filter.Projects
+			}
+			tf, m, err := names(f.Name, include, ps)
+			if err != nil {
+				return nil, nil, fmt.Errorf("%s: %v", f.Name, err)
+			}
+			fs = append(fs, tf)
+			ms = append(ms, m)
+		case input.SliceRange:
+			from, err := input.StringParam(f, 0)
+			if err != nil {
+				return nil, nil, fmt.Errorf("%s: %v", f.Name, err)
+			}
+			to, err := input.StringParam(f, 1)
+			if err != nil {
+				return nil, nil, fmt.Errorf("%s: %v", f.Name, err)
+			}
+			fromInclusive, toInclusive := true, true
+			if len(f.Params) > 2 {
+				fromInclusive, err = input.BoolParam(f, 2)
+				if err != nil {
+					return nil, nil, fmt.Errorf("%s: %v", f.Name, err)
+				}
+			}
+			if len(f.Params) > 3 {
+				toInclusive, err = input.BoolParam(f, 3)
+				if err != nil {
+					return nil, nil, fmt.Errorf("%s: %v", f.Name, err)
+				}
+			}
+			tf, err := slice.Range(seq, from, to, fromInclusive, toInclusive)
+			if err != nil {
+				return nil, nil, fmt.Errorf("%s: %v", f.Name, err)
+			}
+			fs = append(fs, tf)
+		case input.SliceLast:
+			n, err := input.IntParam(f, 0)
+			if err != nil {
+				return nil, nil, fmt.Errorf("%s: %v", f.Name, err)
+			}
+			tf, err := slice.Last(seq, n)
+			if err != nil {
+				return nil, nil, fmt.Errorf("%s: %v", f.Name, err)
+			}
+			fs = append(fs, tf)
+		case input.SliceExclude:
+			cs, err := input.StringParams(f, 0)
+			if err != nil {
+				return nil, nil, fmt.Errorf("%s: %v", f.Name, err)
+			}
+			fs = append(fs, slice.Exclude(seq, cs))
+		case input.SampleMin:
+			n, err := input.IntParam(f, 0)
+			if err != nil {
+				return nil, nil, fmt.Errorf("%s: %v", f.Name, err)
+			}
+			tf, err := sample.MinExecutions(n)
+			if err != nil {
+				return nil, nil, fmt.Errorf("%s: %v", f.Name, err)
+			}
+			fs = append(fs, tf)
+		case input.SampleMax:
+			m, err := input.IntParam(f, 0)
+			if err != nil {
+				return nil, nil, fmt.Errorf("%s: %v", f.Name, err)
+			}
+			var tf data.TransFunc
+			if len(f.Params) < 2 {
+				tf, err = sample.Cap(m)
+			} else {
+				seed, serr := input.IntParam(f, 1)
+				if serr != nil {
+					return nil, nil, fmt.Errorf("%s: %v", f.Name, serr)
+				}
+				tf, err = sample.Subsample(m, int64(seed))
+			}
+			if err != nil {
+				return nil, nil, fmt.Errorf("%s: %v", f.Name, err)
+			}
+			fs = append(fs, tf)
+		case input.Aggregate:
+			tf, err := aggregateFromIn(f)
+			if err != nil {
+				return nil, nil, fmt.Errorf("%s: %v", f.Name, err)
+			}
+			fs = append(fs, tf)
+		case input.NormaliseRatio:
+			var baseline string
+			if len(f.Params) > 0 {
+				b, err := input.StringParam(f, 0)
+				if err != nil {
+					return nil, nil, fmt.Errorf("%s: %v", f.Name, err)
+				}
+				baseline = b
+			}
+			fs = append(fs, normalise.Ratio(baseline))
+		case input.NormaliseZScore:
+			fs = append(fs, normalise.ZScore())
+		case input.NormaliseLog:
+			fs = append(fs, normalise.Log())
+		case input.And, input.Or, input.Not:
+			nfs, nms, err := transFuncsFromIn(f.Funcs, seq)
+			if err != nil {
+				return nil, nil, err
+			}
+			ms = append(ms, nms...)
+			switch f.Name {
+			case input.And:
+				fs = append(fs, filter.And(nfs...))
+			case input.Or:
+				fs = append(fs, filter.Or(nfs...))
+			case input.Not:
+				if len(nfs) != 1 {
+					return nil, nil, fmt.Errorf("%s requires exactly one nested function, was %d", f.Name, len(nfs))
+				}
+				fs = append(fs, filter.Not(nfs[0]))
+			}
+		case input.CpsAny:
+			fs = append(fs, filter.HasChangePoints())
+		case input.CpsRegressions:
+			fs = append(fs, filter.Regressions())
+		case input.CpsMinCategory:
+			p, err := input.IntParam(f, 0)
+			if err != nil {
+				return nil, nil, fmt.Errorf("%s: %v", f.Name, err)
+			}
+			// percentage of the lower bound of the category, e.g. 20 for 20 - 29 %
+			fs = append(fs, filter.MinCategory(data.ChangeCategory(p/10)))
+		case input.CpsRange:
+			from, err := input.StringParam(f, 0)
+			if err != nil {
+				return nil, nil, fmt.Errorf("%s: %v", f.Name, err)
+			}
+			to, err := input.StringParam(f, 1)
+			if err != nil {
+				return nil, nil, fmt.Errorf("%s: %v", f.Name, err)
+			}
+			commits, err := slice.RangeCommits(seq, from, to, true, true)
+			if err != nil {
+				return nil, nil, fmt.Errorf("%s: %v", f.Name, err)
+			}
+			fs = append(fs, filter.ChangePointsAt(commits))
+		default:
+			return nil, nil, fmt.Errorf("Unknown transformation '%s'", f.Name)
+		}
+	}
+	return fs, ms, nil
+}
+
+// aggregateFromIn returns the aggregation with the parameters statistic, percentile (only for percentile) and dispersion
+func aggregateFromIn(f input.Func) (data.TransFunc, error) {
+	statistic, err := input.StringParam(f, 0)
+	if err != nil {
+		return nil, err
+	}
+	pos := 1
+	var p float64
+	if statistic == aggregate.Percentile {
+		p, err = input.Float64Param(f, pos)
+		if err != nil {
+			return nil, err
+		}
+		pos++
+	}
+	var dispersion bool
+	if len(f.Params) > pos {
+		dispersion, err = input.BoolParam(f, pos)
+		if err != nil {
+			return nil, err
 		}
 	}
-	return fs
+	return aggregate.Aggregate(statistic, p, dispersion)
 }
 
 func parseArguments() (input.SubPrograms, input.Config) {
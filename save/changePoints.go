@@ -4,15 +4,18 @@ import (
 	"encoding/csv"
 	"encoding/json"
 	"fmt"
-	"os"
 	"sort"
 	"strings"
+	"time"
 
 	"github.com/sealuzh/gopper/data"
+	"github.com/sealuzh/gopper/store"
 	"github.com/sealuzh/gopper/util"
 )
 
-func ChangePoints(stageNr int, trs []data.TestResults, cps []data.ChangePoints, paths []string) {
+// ChangePoints saves the change points of every test results. Commits with metadata in infos (may be nil) are saved
+// with their author, date and subject.
+func ChangePoints(stageNr int, trs []data.TestResults, cps []data.ChangePoints, paths []string, infos *data.CommitInfos) {
 	lcps := len(cps)
 	ltrs := len(trs)
 	lpaths := len(paths)
@@ -22,40 +25,98 @@ func ChangePoints(stageNr int, trs []data.TestResults, cps []data.ChangePoints,
 	}
 
 	for i, cp := range cps {
-		changePoints := cp.All()
-		cpsAgg := make(map[string]data.ChangePoints, len(cps))
-		for _, c := range changePoints {
-			commit := c.Commit()
-			cpsc := cp.At(commit)
-			cpsAgg[commit] = cpsc
+		if store.IsSQLite(paths[i]) {
+			cpsToStore(trs[i], util.AbsolutePath(paths[i]))
+			continue
+		}
+
+		pcps, err := byProject(cp)
+		if err != nil {
+			fmt.Printf("ERROR - Could not split change points by project: %v\n", err)
+			continue
+		}
+		cpsAgg := make(map[string]map[string]data.ChangePoints, len(pcps))
+		for p, pcp := range pcps {
+			agg := make(map[string]data.ChangePoints)
+			for _, c := range pcp.All() {
+				commit := c.Commit()
+				agg[commit] = pcp.At(commit)
+			}
+			cpsAgg[p] = agg
 		}
 
 		// save json file
-		saveJson(paths[i], cp)
+		saveJson(paths[i], cp, infos, data.TestNamer(trs[i]))
 
 		// save csv
-		saveCsv(paths[i], commitOrder(trs[i]), cpsAgg)
+		commits := commitOrder(trs[i])
+		projects := make([]string, 0, len(commits))
+		for p := range commits {
+			projects = append(projects, p)
+		}
+		sort.Strings(projects)
+		saveCsv(paths[i], projects, commits, cpsAgg, infos)
+	}
+}
+
+// byProject splits change points by the projects of their tests
+func byProject(cps data.ChangePoints) (map[string]data.ChangePoints, error) {
+	ret := make(map[string]data.ChangePoints)
+	for _, c := range cps.All() {
+		commit := c.Commit()
+		for _, tn := range c.TestNames() {
+			tr, ok := c.Get(tn)
+			if !ok {
+				return nil, fmt.Errorf("Inconsistent change point. commit=%s; test=%s", commit, tn)
+			}
+			p := tr.Project()
+			pcps, ok := ret[p]
+			if !ok {
+				pcps = data.NewChangePoints()
+				ret[p] = pcps
+			}
+			ncp, err := data.NewChangePoint(commit, tr)
+			if err != nil {
+				return nil, err
+			}
+			err = pcps.Add(ncp)
+			if err != nil {
+				return nil, err
+			}
+		}
 	}
+	return ret, nil
 }
 
-func commitOrder(trs data.TestResults) []string {
-	ret := make([]string, 0)
+// commitOrder returns the longest commit list of the tests of every project
+func commitOrder(trs data.TestResults) map[string][]string {
+	ret := make(map[string][]string)
 	for tr := range trs.All() {
 		commits := tr.Commits()
-		if len(ret) < len(commits) {
-			ret = commits
+		p := tr.Project()
+		if len(ret[p]) < len(commits) {
+			ret[p] = commits
 		}
 	}
 	return ret
 }
 
-func saveJson(path string, cp data.ChangePoints) {
+func saveJson(path string, cp data.ChangePoints, infos *data.CommitInfos, name func(data.TestResult) string) {
 	op := util.AbsolutePath(outPath(path, ".json"))
-	f, err := os.Create(op)
+	copy, err := data.WithTestNames(cp, name)
+	if err != nil {
+		fmt.Printf("ERROR - Could not name tests of change points: %v\n", err)
+		return
+	}
+	copy, err = data.AttachCommitInfos(copy, infos)
+	if err != nil {
+		fmt.Printf("ERROR - Could not attach commit infos to change points: %v\n", err)
+		return
+	}
+	f, err := util.Create(op)
 	if err != nil {
 		fmt.Printf("ERROR - Could not open output file '%v': %v\n", op, err)
 	} else {
-		copy := cp.Copy()
 		sort.Sort(sort.Reverse(copy))
 		e := json.NewEncoder(f)
 		e.SetIndent("", "    ") // indentation is 4 spaces
@@ -64,9 +125,10 @@ func saveJson(path string, cp data.ChangePoints) {
 	}
 }
 
-func saveCsv(path string, commits []string, cps map[string]data.ChangePoints) {
+// saveCsv saves the number of change points per commit and type. With multiple projects, every line starts with the project.
+func saveCsv(path string, projects []string, commits map[string][]string, cps map[string]map[string]data.ChangePoints, infos *data.CommitInfos) {
 	op := util.AbsolutePath(outPath(path, ".csv"))
-	f, err := os.Create(op)
+	f, err := util.Create(op)
 	if err != nil {
 		fmt.Printf("ERROR - Could not open output file '%v': %v\n", op, err)
 	} else {
@@ -77,29 +139,69 @@ func saveCsv(path string, commits []string, cps map[string]data.ChangePoints) {
 		defer w.Flush()
 
 		cpTypes := data.AllChangePointTypes()
-		columns := len(cpTypes) + 1
+		withInfo := false
+		for _, p := range projects {
+			withInfo = withInfo || hasCommitInfos(commits[p], infos)
+		}
+		withProject := len(projects) > 1
 		// write csv heading line
-		heading := make([]string, columns)
-		heading[0] = "Commit"
-		for i := 1; i < columns; i++ {
-			heading[i] = cpTypes[i-1].String()
+		heading := []string{"Commit"}
+		if withProject {
+			heading = append([]string{"Project"}, heading...)
+		}
+		if withInfo {
+			heading = append(heading, "Author", "Date", "Subject")
+		}
+		for _, t := range cpTypes {
+			heading = append(heading, t.String())
 		}
 		w.Write(heading)
 		w.Flush()
 
 		// write csv content
-		for _, c := range commits {
-			cpt, ok := cps[c]
-			if !ok {
-				w.Write(emptyLine(c, cpTypes))
-			} else {
-				w.Write(nonEmptyLine(c, cpTypes, cpt))
+		for _, p := range projects {
+			for _, c := range commits[p] {
+				var line []string
+				cpt, ok := cps[p][c]
+				if !ok {
+					line = emptyLine(c, cpTypes)
+				} else {
+					line = nonEmptyLine(c, cpTypes, cpt)
+				}
+				if withInfo {
+					line = withCommitInfo(line, infos)
+				}
+				if withProject {
+					line = append([]string{p}, line...)
+				}
+				w.Write(line)
+				w.Flush()
 			}
-			w.Flush()
 		}
 	}
 }
 
+func hasCommitInfos(commits []string, infos *data.CommitInfos) bool {
+	for _, c := range commits {
+		if _, ok := infos.Get(c); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// withCommitInfo inserts author, date and subject of the commit after the commit of a line
+func withCommitInfo(line []string, infos *data.CommitInfos) []string {
+	ci, _ := infos.Get(line[0])
+	var date string
+	if !ci.Date.IsZero() {
+		date = ci.Date.Format(time.RFC3339)
+	}
+	ret := make([]string, 0, len(line)+3)
+	ret = append(ret, line[0], ci.Author, date, ci.Subject)
+	return append(ret, line[1:]...)
+}
+
 func emptyLine(commit string, cpTypes []data.ChangePointType) []string {
 	l := len(cpTypes) + 1
 	line := make([]string, l)
@@ -128,14 +230,31 @@ func nonEmptyLine(commit string, cpTypes []data.ChangePointType, cps data.Change
 	return line
 }
 
+// outPath appends suffix to path if not present, before a possible compression extension
 func outPath(path string, suffix string) string {
-	if strings.HasSuffix(path, suffix) {
-		return path
+	p, ext := util.CompressionExt(path)
+	if !strings.HasSuffix(p, suffix) {
+		p += suffix
 	}
-	return path + suffix
+	return p + ext
 }
 
 type changePointTypes struct {
 	Im  int
 	Reg int
 }
+
+// cpsToStore stores the change points of the tests without their executions
+func cpsToStore(trs data.TestResults, path string) {
+	s, err := store.OpenSQLite(path)
+	if err != nil {
+		fmt.Printf("ERROR - Could not open store '%v': %v\n", path, err)
+		return
+	}
+	defer s.Close()
+
+	err = s.SaveChangePoints(trs)
+	if err != nil {
+		fmt.Printf("ERROR - Could not save change points in store '%v': %v\n", path, err)
+	}
+}
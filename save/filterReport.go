@@ -0,0 +1,86 @@
+package save
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/sealuzh/gopper/data"
+	"github.com/sealuzh/gopper/data/input"
+	"github.com/sealuzh/gopper/util"
+)
+
+// FilterReports saves the filter decisions of every test results next to its output file (e.g. 'out.filter.csv' for
+// 'out.csv') in format (CSV or JSON)
+func FilterReports(reports []*data.FilterReport, outPaths []string, format string) {
+	if len(outPaths) == 0 || format == "" {
+		return
+	}
+	if len(reports) != len(outPaths) {
+		fmt.Printf("ERROR - length of filter reports (%d) and paths (%d) not equal\n", len(reports), len(outPaths))
+		return
+	}
+
+	for i, r := range reports {
+		ds := []data.FilterDecision{}
+		if r != nil {
+			ds = r.Decisions()
+		}
+		op := util.AbsolutePath(filterReportPath(outPaths[i], format))
+		f, err := util.Create(op)
+		if err != nil {
+			fmt.Printf("ERROR - Could not open output file '%v': %v\n", op, err)
+			continue
+		}
+		switch format {
+		case input.ReportJSON:
+			e := json.NewEncoder(f)
+			e.SetIndent("", "    ")
+			err = e.Encode(ds)
+		default:
+			w := csv.NewWriter(f)
+			w.Comma = comma
+			// with multiple projects, every line starts with the project, and with multiple configurations, the test is
+			// followed by its configuration
+			withProject := len(decisionValues(ds, func(d data.FilterDecision) string { return d.Project })) > 1
+			withConfiguration := len(decisionValues(ds, func(d data.FilterDecision) string { return d.Configuration })) > 1
+			w.Write(decisionLine(withProject, withConfiguration, "Project", "Test", "Configuration", "Transformation", "Reason"))
+			for _, d := range ds {
+				w.Write(decisionLine(withProject, withConfiguration, d.Project, d.Test, d.Configuration, d.Transformation, d.Reason))
+			}
+			w.Flush()
+			err = w.Error()
+		}
+		if err != nil {
+			fmt.Printf("ERROR - Could not write filter report '%v': %v\n", op, err)
+		}
+		f.Close()
+	}
+}
+
+func decisionValues(ds []data.FilterDecision, value func(data.FilterDecision) string) map[string]struct{} {
+	ret := make(map[string]struct{})
+	for _, d := range ds {
+		ret[value(d)] = struct{}{}
+	}
+	return ret
+}
+
+func decisionLine(withProject, withConfiguration bool, project, test, configuration, transformation, reason string) []string {
+	ret := make([]string, 0, 5)
+	if withProject {
+		ret = append(ret, project)
+	}
+	ret = append(ret, test)
+	if withConfiguration {
+		ret = append(ret, configuration)
+	}
+	return append(ret, transformation, reason)
+}
+
+func filterReportPath(path, format string) string {
+	p, ext := util.CompressionExt(path)
+	return strings.TrimSuffix(p, filepath.Ext(p)) + ".filter." + format + ext
+}
@@ -0,0 +1,91 @@
+package save
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/sealuzh/gopper/data"
+	"github.com/sealuzh/gopper/data/input"
+)
+
+func TestFilterReportPath(t *testing.T) {
+	tests := map[string]string{
+		"out.csv":        "out.filter.csv",
+		"dir/out.csv.gz": "dir/out.filter.csv.gz",
+		"out":            "out.filter.csv",
+	}
+	for p, expected := range tests {
+		if fp := filterReportPath(p, input.ReportCSV); fp != expected {
+			t.Errorf("filterReportPath(%q) = %q, expected %q", p, fp, expected)
+		}
+	}
+}
+
+func filterReport(decisions ...data.FilterDecision) *data.FilterReport {
+	r := data.NewFilterReport()
+	for _, d := range decisions {
+		r.Add(d)
+	}
+	return r
+}
+
+func TestFilterReports(t *testing.T) {
+	dir, err := ioutil.TempDir("", "gopper")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	single := filterReport(data.FilterDecision{Project: "p", Test: "a", Configuration: "default", Transformation: "minMean [0.01]", Reason: "mean 0.004 < 0.01"})
+	several := filterReport(
+		data.FilterDecision{Project: "p", Test: "a", Configuration: "default", Transformation: "minVersions [2]", Reason: "1 versions"},
+		data.FilterDecision{Project: "q", Test: "a", Configuration: "default", Transformation: "minVersions [2]"},
+	)
+	configurations := filterReport(
+		data.FilterDecision{Project: "p", Test: "a", Configuration: "jdk8", Transformation: "minVersions [2]", Reason: "1 versions"},
+		data.FilterDecision{Project: "p", Test: "a", Configuration: "jdk11", Transformation: "minVersions [2]", Reason: "1 versions"},
+	)
+	paths := []string{filepath.Join(dir, "single.csv"), filepath.Join(dir, "several.csv"), filepath.Join(dir, "configurations.csv")}
+
+	FilterReports([]*data.FilterReport{single, several, configurations}, paths, input.ReportCSV)
+	expected := map[string]string{
+		"single.filter.csv":         "Test;Transformation;Reason\na;minMean [0.01];mean 0.004 < 0.01\n",
+		"several.filter.csv":        "Project;Test;Transformation;Reason\np;a;minVersions [2];1 versions\nq;a;minVersions [2];\n",
+		"configurations.filter.csv": "Test;Configuration;Transformation;Reason\na;jdk8;minVersions [2];1 versions\na;jdk11;minVersions [2];1 versions\n",
+	}
+	for name, content := range expected {
+		b, err := ioutil.ReadFile(filepath.Join(dir, name))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(b) != content {
+			t.Errorf("%s = %q, expected %q", name, b, content)
+		}
+	}
+
+	FilterReports([]*data.FilterReport{several, nil, configurations}, paths, input.ReportJSON)
+	b, err := ioutil.ReadFile(filepath.Join(dir, "single.filter.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var ds []data.FilterDecision
+	if err := json.Unmarshal(b, &ds); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(ds, several.Decisions()) {
+		t.Errorf("Decisions = %v, expected %v", ds, several.Decisions())
+	}
+	// no decisions without report
+	b, err = ioutil.ReadFile(filepath.Join(dir, "several.filter.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s := strings.TrimSpace(string(b)); s != "[]" {
+		t.Errorf("Report without decisions = %s", s)
+	}
+}
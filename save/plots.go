@@ -22,20 +22,34 @@ const (
 	yLabel      = "Time"
 	extension   = ".png"
 	minPlotData = 3
+	// labels of commits with metadata
+	shortSHALength = 7
+	// tick label suffix of versions missing in a test
+	missingLabel   = " (missing)"
+	tickDateFormat = "2006-01-02"
+	// separator of parameters in file names
+	fileParamSep = "_"
 )
 
-var multipleTestNames = 0
-
 type pd struct {
 	plotDir string
 	data    data.TestResult
+	// metadata of the commits, may be nil
+	infos *data.CommitInfos
+	// whether tests are namespaced by their project and configuration in titles and file names
+	withProject       bool
+	withConfiguration bool
 }
 
-func Plots(ctx context.Context, in data.TestResults, plotDir string) {
+// Plots plots the versions of every test. Versions are labelled with the date of the commit if infos has the metadata
+// of the commit (infos may be nil).
+func Plots(ctx context.Context, in data.TestResults, plotDir string, infos *data.CommitInfos) {
 	//TODO: support multiple stages, e.g. return parameterless function
 	l := in.Len()
 	fmt.Printf("  Plot time series for %d tests\n", l)
 	handleDirectory(plotDir)
+	withProject := len(data.Projects(in)) > 1
+	withConfiguration := len(data.Configurations(in)) > 1
 
 	ch := make(chan pd)
 	done := make(chan int)
@@ -47,8 +61,11 @@ func Plots(ctx context.Context, in data.TestResults, plotDir string) {
 			panic(fmt.Sprintf("ERROR - Could not retrieve test '%s' from results", name))
 		}
 		ch <- pd{
-			plotDir: plotDir,
-			data:    td,
+			plotDir:           plotDir,
+			data:              td,
+			infos:             infos,
+			withProject:       withProject,
+			withConfiguration: withConfiguration,
 		}
 	}
 	close(ch)
@@ -93,12 +110,19 @@ func printPlot(c <-chan pd, done chan<- int) {
 			d := pd.data
 			plotDir := pd.plotDir
 
-			title := d.Test()
+			project, configuration := "", ""
+			if pd.withProject {
+				project = d.Project()
+			}
+			if pd.withConfiguration {
+				configuration = d.Configuration()
+			}
+			title := data.TestID(project, d.Test(), configuration)
 
 			fmt.Printf("    Plot for test '%s'\n", title)
 
 			//plotData, cps, xTicks := plotData(d)
-			plotData, cps, xTicks := boxPlots(d)
+			plotData, cps, xTicks := boxPlots(d, pd.infos)
 			dataLength := len(plotData)
 			if dataLength < minPlotData {
 				fmt.Printf("    DEBUG - Not enough plot data available: %d\n", dataLength)
@@ -106,12 +130,19 @@ func printPlot(c <-chan pd, done chan<- int) {
 			}
 
 			p.Title.Text = title
+			if params := d.Parameters(); len(params) > 0 {
+				p.Title.Text = fmt.Sprintf("%s\n%s", data.TestID(project, d.BaseName(), configuration), data.ParametersName(params, ", "))
+			}
 			p.X.Label.Text = xLabel
 			p.X.Tick.Marker = xTicks
 			p.X.Tick.Label.Rotation = math.Pi / 2
 			p.X.Tick.Label.XAlign = draw.XRight
 			p.X.Tick.Label.YAlign = draw.YCenter
 			p.Y.Label.Text = yLabel
+			if u := d.Unit(); u.Name != "" {
+				p.Y.Label.Text = u.Name
+			}
+			p.Y.Label.Text = d.Normalisation().Label(p.Y.Label.Text)
 
 			// display boxPlots
 			p.Add(plotData...)
@@ -131,15 +162,12 @@ func printPlot(c <-chan pd, done chan<- int) {
 			cpPoints.Radius = 2
 			p.Add(cpPoints)
 			*/
-			// filename
-			i := strings.Index(title, "[")
-			fileName := title
-			if i != -1 {
-				multipleTestNames += 1
-				fileName = fmt.Sprintf("%s%d", fileName[:i], multipleTestNames)
+			fileName := filepath.Join(plotDir, plotFileName(d, project, configuration))
+			err = os.MkdirAll(filepath.Dir(fileName), 0777)
+			if err != nil {
+				fmt.Printf("    ERROR - Could not create plot directory: %v\n", err)
+				return
 			}
-			fileName = fmt.Sprintf("%s%s", fileName, extension)
-			fileName = filepath.Join(plotDir, fileName)
 			err = p.Save(30*vg.Centimeter, 20*vg.Centimeter, fileName)
 			if err != nil {
 				fmt.Printf("    ERROR - Could not save plot: %v\n", err)
@@ -150,21 +178,42 @@ func printPlot(c <-chan pd, done chan<- int) {
 	done <- counter
 }
 
-func boxPlots(testResult data.TestResult) ([]pl.Plotter, []pl.Plotter, VersionTicker) {
-	commits := testResult.Commits()
+// plotFileName returns the file name of the plot of a test relative to the plot directory. Plots of parameterised tests
+// are grouped in a directory per base name and named by their parameters. Tests of a project and configuration (may be
+// empty) are prefixed with the project and suffixed with the configuration.
+func plotFileName(d data.TestResult, project, configuration string) string {
+	fileName := fileNameOf(data.TestID(project, d.BaseName(), configuration))
+	if params := d.Parameters(); len(params) > 0 {
+		fileName = filepath.Join(fileName, fileNameOf(data.ParametersName(params, fileParamSep)))
+	}
+	return fmt.Sprintf("%s%s", fileName, extension)
+}
+
+func fileNameOf(s string) string {
+	// test IDs contain slashes, e.g. project prefixes
+	s = strings.Replace(s, "/", "_", -1)
+	return strings.Replace(s, " ", "", -1)
+}
+
+// boxPlots returns the box plots of the versions of a test. Versions that the test is missing (its gaps) have no box
+// plot and are labelled as missing.
+func boxPlots(testResult data.TestResult, infos *data.CommitInfos) ([]pl.Plotter, []pl.Plotter, VersionTicker) {
+	commits := withGaps(testResult)
 	cps := testResult.ChangePoints()
 
 	lc := len(commits)
 	lcps := cps.Len()
 
-	bpsData := make([]pl.Plotter, 0, lc-lcps)
+	bpsData := make([]pl.Plotter, 0, lc)
 	bpsCps := make([]pl.Plotter, 0, lcps)
 	ticks := make([]pl.Tick, lc)
 
 	for i, c := range commits {
+		ticks[i].Value = float64(i)
 		ers, ok := testResult.ExecutionResults(c)
 		if !ok {
-			panic(fmt.Sprintf("Inconsistent test result: %s @ %s", testResult.Test(), c))
+			ticks[i].Label = tickLabel(c, infos) + missingLabel
+			continue
 		}
 		b, err := plotter.NewBoxPlot(vg.Points(20), float64(i), plotter.Values(ers.Values()))
 		if err != nil {
@@ -185,13 +234,31 @@ func boxPlots(testResult data.TestResult) ([]pl.Plotter, []pl.Plotter, VersionTi
 			bpsData = append(bpsData, b)
 		}
 
-		ticks[i].Label = c
-		ticks[i].Value = float64(i)
+		ticks[i].Label = tickLabel(c, infos)
 	}
 
 	return bpsData, bpsCps, VersionTicker(ticks)
 }
 
+// withGaps returns the commits of tr with the missing versions of its gaps
+func withGaps(tr data.TestResult) []string {
+	commits := tr.Commits()
+	gaps := tr.Gaps()
+	if len(gaps) == 0 {
+		return commits
+	}
+	missing := make(map[string][]string, len(gaps))
+	for _, g := range gaps {
+		missing[g.Before] = g.Missing
+	}
+	ret := make([]string, 0, len(commits))
+	for _, c := range commits {
+		ret = append(ret, c)
+		ret = append(ret, missing[c]...)
+	}
+	return ret
+}
+
 /*func plotData(testResult data.TestResult) (plotter.XYs, plotter.XYs, VersionTicker) {
 	d := testResult.ExecutionResults
 	l := len(d)
@@ -219,6 +286,19 @@ func boxPlots(testResult data.TestResult) ([]pl.Plotter, []pl.Plotter, VersionTi
 	return data, cps, VersionTicker(ticks)
 }*/
 
+// tickLabel labels a commit with its abbreviated SHA and date, if the commit has metadata
+func tickLabel(commit string, infos *data.CommitInfos) string {
+	ci, ok := infos.Get(commit)
+	if !ok {
+		return commit
+	}
+	sha := commit
+	if len(sha) > shortSHALength {
+		sha = sha[:shortSHALength]
+	}
+	return fmt.Sprintf("%s %s", sha, ci.Date.Format(tickDateFormat))
+}
+
 type VersionTicker []pl.Tick
 
 func (t VersionTicker) Ticks(min, max float64) []pl.Tick {
@@ -0,0 +1,46 @@
+package save
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/sealuzh/gopper/data"
+)
+
+func TestWithGaps(t *testing.T) {
+	trs := data.NewTestResults(data.DefaultHeading)
+	for _, c := range []string{"c1", "c4", "c5"} {
+		err := trs.Add(&data.ExecutionResult{Project: "p", Version: c, SHA: c, Configuration: "default", Test: "a", RawVal: 1})
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	tr, _ := trs.Get(data.TestID("p", "a", "default"))
+
+	// not aligned without gaps
+	if commits := withGaps(tr); !reflect.DeepEqual(commits, []string{"c1", "c4", "c5"}) {
+		t.Errorf("Commits without gaps = %v", commits)
+	}
+
+	tr.SetGaps([]data.Gap{{Before: "c1", After: "c4", Missing: []string{"c2", "c3"}}})
+	if commits := withGaps(tr); !reflect.DeepEqual(commits, []string{"c1", "c2", "c3", "c4", "c5"}) {
+		t.Errorf("Commits with gaps = %v", commits)
+	}
+}
+
+func TestPlotFileName(t *testing.T) {
+	tests := []struct {
+		test, project, configuration, expected string
+	}{
+		{"BenchmarkSort", "", "", "BenchmarkSort.png"},
+		{"BenchmarkSort", "p", "", "p_BenchmarkSort.png"},
+		{"BenchmarkSort", "", "jdk8", "BenchmarkSort@jdk8.png"},
+		{"Bench.sort[size=10, algo=quick]", "", "", "Bench.sort/size=10_algo=quick.png"},
+	}
+	for _, test := range tests {
+		tr := data.NewTestResult("p", test.test, "default")
+		if n := plotFileName(tr, test.project, test.configuration); n != test.expected {
+			t.Errorf("plotFileName(%q, %q, %q) = %q, expected %q", test.test, test.project, test.configuration, n, test.expected)
+		}
+	}
+}
@@ -0,0 +1,26 @@
+package save
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/sealuzh/gopper/data"
+	"github.com/sealuzh/gopper/util"
+)
+
+// Quality saves the data-quality reports of the inputs as a JSON file
+func Quality(path string, qs []data.Quality) {
+	op := util.AbsolutePath(outPath(path, ".json"))
+	f, err := util.Create(op)
+	if err != nil {
+		fmt.Printf("ERROR - Could not open output file '%v': %v\n", op, err)
+		return
+	}
+	defer f.Close()
+	e := json.NewEncoder(f)
+	e.SetIndent("", "    ")
+	err = e.Encode(qs)
+	if err != nil {
+		fmt.Printf("ERROR - Could not write data-quality report '%v': %v\n", op, err)
+	}
+}
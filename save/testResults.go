@@ -3,13 +3,15 @@ package save
 import (
 	"encoding/csv"
 	"fmt"
-	"os"
 
 	"github.com/sealuzh/gopper/data"
+	"github.com/sealuzh/gopper/store"
 	"github.com/sealuzh/gopper/util"
 )
 
-func TestResults(stageNr int, d []data.TestResults, outPaths []string) {
+// TestResults saves test results as CSV files, or in a SQLite store if the path has a SQLite extension.
+// If dialects is nil, the files are in the hopper dialect.
+func TestResults(stageNr int, d []data.TestResults, outPaths []string, dialects []data.CSVDialect) {
 	lo := len(outPaths)
 	ld := len(d)
 	if len(outPaths) == 0 {
@@ -22,17 +24,35 @@ func TestResults(stageNr int, d []data.TestResults, outPaths []string) {
 	}
 
 	for i, r := range d {
+		if store.IsSQLite(outPaths[i]) {
+			toStore(r, util.AbsolutePath(outPaths[i]))
+			continue
+		}
+
+		dialect := data.HopperDialect()
+		if dialects != nil {
+			dialect = dialects[i]
+		}
+		heading := dialect.Heading(r.Heading())
+		cf, err := data.NewCSVFormat(dialect, heading)
+		if err != nil {
+			fmt.Printf("ERROR - Invalid CSV dialect for output file '%v': %v\n", outPaths[i], err)
+			continue
+		}
+
 		outPath := util.AbsolutePath(outPaths[i])
-		f, err := os.Create(outPath)
+		f, err := util.Create(outPath)
 		if err != nil {
 			fmt.Printf("ERROR - Could not open output file '%v': %v", outPath, err)
 		} else {
 			defer f.Close()
 			w := csv.NewWriter(f)
-			w.Comma = comma
+			w.Comma = dialect.Comma
 			defer w.Flush()
-			w.Write(r.Heading())
-			w.Flush()
+			if heading != nil {
+				w.Write(heading)
+				w.Flush()
+			}
 			for _, n := range r.TestNames() {
 				rs, ok := r.Get(n)
 				if !ok {
@@ -46,7 +66,7 @@ func TestResults(stageNr int, d []data.TestResults, outPaths []string) {
 						panic(fmt.Sprintf("Inconsistent test result: %s @ %s", r, c))
 					}
 					for _, r := range ers.All() {
-						w.Write(r.AsStringArray())
+						w.WriteAll(cf.Records(r))
 					}
 				}
 				w.Flush()
@@ -54,3 +74,17 @@ func TestResults(stageNr int, d []data.TestResults, outPaths []string) {
 		}
 	}
 }
+
+func toStore(trs data.TestResults, path string) {
+	s, err := store.OpenSQLite(path)
+	if err != nil {
+		fmt.Printf("ERROR - Could not open store '%v': %v\n", path, err)
+		return
+	}
+	defer s.Close()
+
+	err = s.Save(trs)
+	if err != nil {
+		fmt.Printf("ERROR - Could not save test results in store '%v': %v\n", path, err)
+	}
+}
@@ -0,0 +1,552 @@
+package store
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/sealuzh/gopper/data"
+)
+
+const (
+	driver = "sqlite3"
+	// schemaVersion is stored in the user_version of a database and increased with every migration
+	schemaVersion = 8
+	schema        = `
+CREATE TABLE IF NOT EXISTS heading (
+	position INTEGER PRIMARY KEY,
+	name TEXT NOT NULL
+);
+CREATE TABLE IF NOT EXISTS commits (
+	sha TEXT PRIMARY KEY,
+	position INTEGER NOT NULL
+);
+CREATE TABLE IF NOT EXISTS execution_results (
+	id INTEGER PRIMARY KEY AUTOINCREMENT,
+	project TEXT NOT NULL,
+	version TEXT NOT NULL,
+	sha TEXT NOT NULL REFERENCES commits (sha),
+	configuration TEXT NOT NULL,
+	test TEXT NOT NULL,
+	raw_val REAL NOT NULL,
+	timestamp TEXT NOT NULL DEFAULT '',
+	unit TEXT NOT NULL DEFAULT '',
+	metrics TEXT NOT NULL DEFAULT '',
+	metric_units TEXT NOT NULL DEFAULT '',
+	higher_is_better INTEGER NOT NULL DEFAULT -1,
+	normalisation TEXT NOT NULL DEFAULT '',
+	dispersion INTEGER NOT NULL DEFAULT 0
+);
+CREATE INDEX IF NOT EXISTS execution_results_test ON execution_results (project, test, configuration, sha);
+CREATE TABLE IF NOT EXISTS change_points (
+	project TEXT NOT NULL,
+	test TEXT NOT NULL,
+	configuration TEXT NOT NULL,
+	sha TEXT NOT NULL REFERENCES commits (sha),
+	regression INTEGER NOT NULL,
+	category INTEGER NOT NULL,
+	PRIMARY KEY (project, test, configuration, sha)
+);`
+)
+
+// migrations add the columns introduced after the first schema version. Databases created by an older version are
+// upgraded by adding the missing columns with their defaults.
+var migrations = []struct {
+	version int
+	table   string
+	column  string
+	def     string
+}{
+	{2, "execution_results", "timestamp", "TEXT NOT NULL DEFAULT ''"},
+	{3, "execution_results", "unit", "TEXT NOT NULL DEFAULT ''"},
+	{4, "execution_results", "metrics", "TEXT NOT NULL DEFAULT ''"},
+	{5, "execution_results", "metric_units", "TEXT NOT NULL DEFAULT ''"},
+	// -1 for executions saved without direction, which is derived from the unit
+	{6, "execution_results", "higher_is_better", "INTEGER NOT NULL DEFAULT -1"},
+	{7, "execution_results", "normalisation", "TEXT NOT NULL DEFAULT ''"},
+	{8, "execution_results", "dispersion", "INTEGER NOT NULL DEFAULT 0"},
+}
+
+var Extensions = []string{".sqlite", ".sqlite3", ".db"}
+
+// IsSQLite checks whether a path is a SQLite database by its extension
+func IsSQLite(path string) bool {
+	ext := filepath.Ext(path)
+	for _, e := range Extensions {
+		if ext == e {
+			return true
+		}
+	}
+	return false
+}
+
+// SQLite stores test results and their change points in a SQLite database.
+// Commits are ordered by their first insertion into the database.
+type SQLite struct {
+	db *sql.DB
+}
+
+func OpenSQLite(path string) (*SQLite, error) {
+	db, err := sql.Open(driver, path)
+	if err != nil {
+		return nil, err
+	}
+	_, err = db.Exec(schema)
+	if err != nil {
+		db.Close()
+		return nil, fmt.Errorf("Could not create schema in '%s': %v", path, err)
+	}
+	err = migrate(db)
+	if err != nil {
+		db.Close()
+		return nil, fmt.Errorf("Could not migrate schema in '%s': %v", path, err)
+	}
+	return &SQLite{db: db}, nil
+}
+
+func migrate(db *sql.DB) error {
+	var version int
+	err := db.QueryRow("PRAGMA user_version").Scan(&version)
+	if err != nil {
+		return err
+	}
+	if version > schemaVersion {
+		return fmt.Errorf("Schema version %d is newer than the supported version %d", version, schemaVersion)
+	}
+	if version == schemaVersion {
+		return nil
+	}
+
+	tx, err := db.Begin()
+	if err != nil {
+		return err
+	}
+	for _, m := range migrations {
+		if m.version <= version {
+			continue
+		}
+		exists, err := hasColumn(tx, m.table, m.column)
+		if err != nil {
+			tx.Rollback()
+			return err
+		}
+		if exists {
+			// created by the current schema
+			continue
+		}
+		_, err = tx.Exec(fmt.Sprintf("ALTER TABLE %s ADD COLUMN %s %s", m.table, m.column, m.def))
+		if err != nil {
+			tx.Rollback()
+			return err
+		}
+	}
+	// PRAGMA does not support placeholders
+	_, err = tx.Exec(fmt.Sprintf("PRAGMA user_version = %d", schemaVersion))
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+	return tx.Commit()
+}
+
+func hasColumn(tx *sql.Tx, table, column string) (bool, error) {
+	rows, err := tx.Query(fmt.Sprintf("PRAGMA table_info(%s)", table))
+	if err != nil {
+		return false, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var (
+			cid     int
+			name    string
+			typ     string
+			notNull bool
+			def     sql.NullString
+			pk      int
+		)
+		if err := rows.Scan(&cid, &name, &typ, &notNull, &def, &pk); err != nil {
+			return false, err
+		}
+		if name == column {
+			return true, nil
+		}
+	}
+	return false, rows.Err()
+}
+
+func (s *SQLite) Close() error {
+	return s.db.Close()
+}
+
+// Query restricts the test results loaded from a store. Empty elements do not restrict the results.
+// From and To are inclusive commits.
+type Query struct {
+	Project string
+	Tests   []string
+	From    string
+	To      string
+}
+
+func (s *SQLite) Heading() ([]string, error) {
+	rows, err := s.db.Query("SELECT name FROM heading ORDER BY position")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var ret []string
+	for rows.Next() {
+		var n string
+		if err := rows.Scan(&n); err != nil {
+			return nil, err
+		}
+		ret = append(ret, n)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	if len(ret) == 0 {
+		return data.DefaultHeading, nil
+	}
+	return ret, nil
+}
+
+// Commits returns all commits in order
+func (s *SQLite) Commits() ([]string, error) {
+	rows, err := s.db.Query("SELECT sha FROM commits ORDER BY position")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var ret []string
+	for rows.Next() {
+		var c string
+		if err := rows.Scan(&c); err != nil {
+			return nil, err
+		}
+		ret = append(ret, c)
+	}
+	return ret, rows.Err()
+}
+
+func (s *SQLite) commitPosition(commit string) (int, error) {
+	var pos int
+	err := s.db.QueryRow("SELECT position FROM commits WHERE sha = ?", commit).Scan(&pos)
+	if err == sql.ErrNoRows {
+		return 0, fmt.Errorf("Unknown commit '%s'", commit)
+	}
+	return pos, err
+}
+
+// where returns the where clause and its arguments of a query on a table with alias t joined with commits c
+func (s *SQLite) where(q Query) (string, []interface{}, error) {
+	conds := []string{"1 = 1"}
+	var args []interface{}
+	if q.Project != "" {
+		conds = append(conds, "t.project = ?")
+		args = append(args, q.Project)
+	}
+	if len(q.Tests) > 0 {
+		conds = append(conds, "t.test IN (?"+strings.Repeat(", ?", len(q.Tests)-1)+")")
+		for _, tn := range q.Tests {
+			args = append(args, tn)
+		}
+	}
+	if q.From != "" {
+		pos, err := s.commitPosition(q.From)
+		if err != nil {
+			return "", nil, err
+		}
+		conds = append(conds, "c.position >= ?")
+		args = append(args, pos)
+	}
+	if q.To != "" {
+		pos, err := s.commitPosition(q.To)
+		if err != nil {
+			return "", nil, err
+		}
+		conds = append(conds, "c.position <= ?")
+		args = append(args, pos)
+	}
+	return strings.Join(conds, " AND "), args, nil
+}
+
+// TestResults loads the test results and change points matching a query
+func (s *SQLite) TestResults(q Query) (data.TestResults, error) {
+	heading, err := s.Heading()
+	if err != nil {
+		return nil, err
+	}
+	where, args, err := s.where(q)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := s.db.Query("SELECT t.project, t.version, t.sha, t.configuration, t.test, t.raw_val, t.timestamp, t.unit, t.metrics, t.metric_units, t.higher_is_better, t.normalisation, t.dispersion "+
+		"FROM execution_results t JOIN commits c ON c.sha = t.sha WHERE "+where+" ORDER BY c.position, t.id", args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	res := data.NewTestResults(heading)
+	for rows.Next() {
+		var er data.ExecutionResult
+		var metrics, metricUnits, normalisation string
+		var higherIsBetter int
+		err := rows.Scan(&er.Project, &er.Version, &er.SHA, &er.Configuration, &er.Test, &er.RawVal, &er.Timestamp, &er.Unit, &metrics, &metricUnits, &higherIsBetter, &normalisation, &er.Dispersion)
+		if err != nil {
+			return nil, err
+		}
+		if metrics != "" {
+			err := json.Unmarshal([]byte(metrics), &er.Metrics)
+			if err != nil {
+				return nil, fmt.Errorf("Invalid metrics of test '%s': %v", er.Test, err)
+			}
+		}
+		if metricUnits != "" {
+			err := json.Unmarshal([]byte(metricUnits), &er.MetricUnits)
+			if err != nil {
+				return nil, fmt.Errorf("Invalid metric units of test '%s': %v", er.Test, err)
+			}
+		}
+		res.Add(&er)
+
+		// unit direction and normalisation of the test are stored with every execution
+		tr, _ := res.Get(data.TestID(er.Project, er.Test, er.Configuration))
+		if higherIsBetter >= 0 {
+			tr.SetUnit(data.Unit{Name: tr.Unit().Name, HigherIsBetter: higherIsBetter == 1})
+		}
+		if normalisation != "" {
+			var n data.Normalisation
+			err := json.Unmarshal([]byte(normalisation), &n)
+			if err != nil {
+				return nil, fmt.Errorf("Invalid normalisation of test '%s': %v", er.Test, err)
+			}
+			tr.SetNormalisation(n)
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	err = s.addChangePoints(res, where, args)
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+func (s *SQLite) addChangePoints(res data.TestResults, where string, args []interface{}) error {
+	rows, err := s.db.Query("SELECT t.project, t.test, t.configuration, t.sha, t.regression, t.category FROM change_points t JOIN commits c ON c.sha = t.sha WHERE "+where+" ORDER BY c.position", args...)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var project, test, configuration, commit string
+		var regression bool
+		var category int
+		if err := rows.Scan(&project, &test, &configuration, &commit, &regression, &category); err != nil {
+			return err
+		}
+		tr, ok := res.Get(data.TestID(project, test, configuration))
+		if !ok {
+			continue
+		}
+		// the stored type, which may not be derivable from the queried commits
+		cp, err := data.NewChangePointOfType(commit, tr, data.NewDefaultChangePointType(regression, data.ChangeCategory(category)))
+		if err != nil {
+			// change point not within the queried commits
+			continue
+		}
+		err = tr.AddChangePoint(cp)
+		if err != nil {
+			return err
+		}
+	}
+	return rows.Err()
+}
+
+// Save stores test results and their change points. Stored executions and change points of the same test and
+// commit are replaced.
+func (s *SQLite) Save(trs data.TestResults) error {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return err
+	}
+	err = save(tx, trs)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+	return tx.Commit()
+}
+
+func save(tx *sql.Tx, trs data.TestResults) error {
+	var headings int
+	err := tx.QueryRow("SELECT COUNT(*) FROM heading").Scan(&headings)
+	if err != nil {
+		return err
+	}
+	if headings == 0 {
+		for i, h := range trs.Heading() {
+			_, err := tx.Exec("INSERT INTO heading (position, name) VALUES (?, ?)", i, h)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	insCommit, err := tx.Prepare("INSERT OR IGNORE INTO commits (sha, position) VALUES (?, (SELECT COUNT(*) FROM commits))")
+	if err != nil {
+		return err
+	}
+	defer insCommit.Close()
+	delErs, err := tx.Prepare("DELETE FROM execution_results WHERE project = ? AND test = ? AND configuration = ? AND sha = ?")
+	if err != nil {
+		return err
+	}
+	defer delErs.Close()
+	insEr, err := tx.Prepare("INSERT INTO execution_results (project, version, sha, configuration, test, raw_val, timestamp, unit, metrics, metric_units, higher_is_better, normalisation, dispersion) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)")
+	if err != nil {
+		return err
+	}
+	defer insEr.Close()
+	delCp, err := tx.Prepare("DELETE FROM change_points WHERE project = ? AND test = ? AND configuration = ? AND sha = ?")
+	if err != nil {
+		return err
+	}
+	defer delCp.Close()
+	insCp, err := tx.Prepare("INSERT INTO change_points (project, test, configuration, sha, regression, category) VALUES (?, ?, ?, ?, ?, ?)")
+	if err != nil {
+		return err
+	}
+	defer insCp.Close()
+
+	for tr := range trs.All() {
+		project := tr.Project()
+		test := tr.Test()
+		configuration := tr.Configuration()
+		higherIsBetter := 0
+		if tr.Unit().HigherIsBetter {
+			higherIsBetter = 1
+		}
+		normalisation := ""
+		if n := tr.Normalisation(); n.IsNormalised() {
+			b, err := json.Marshal(n)
+			if err != nil {
+				return err
+			}
+			normalisation = string(b)
+		}
+		for _, c := range tr.Commits() {
+			ers, ok := tr.ExecutionResults(c)
+			if !ok {
+				return fmt.Errorf("Inconsistent test result: %s @ %s", tr.ID(), c)
+			}
+			if _, err := insCommit.Exec(c); err != nil {
+				return err
+			}
+			if _, err := delErs.Exec(project, test, configuration, c); err != nil {
+				return err
+			}
+			if _, err := delCp.Exec(project, test, configuration, c); err != nil {
+				return err
+			}
+			for _, er := range ers.All() {
+				metrics, err := jsonColumn(er.Metrics, len(er.Metrics))
+				if err != nil {
+					return err
+				}
+				metricUnits, err := jsonColumn(er.MetricUnits, len(er.MetricUnits))
+				if err != nil {
+					return err
+				}
+				_, err = insEr.Exec(er.Project, er.Version, er.SHA, er.Configuration, er.Test, er.RawVal, er.Timestamp, er.Unit, metrics, metricUnits, higherIsBetter, normalisation, er.Dispersion)
+				if err != nil {
+					return err
+				}
+			}
+		}
+
+		for _, cp := range tr.ChangePoints().All() {
+			t := cp.Type()
+			_, err := insCp.Exec(project, test, configuration, cp.Commit(), t.IsRegression(), int(t.Category()))
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// SaveChangePoints stores the change points of test results without their executions, e.g. after an analysis of
+// test results that are already stored. Stored change points of the same test and commit are replaced.
+func (s *SQLite) SaveChangePoints(trs data.TestResults) error {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return err
+	}
+	err = saveChangePoints(tx, trs)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+	return tx.Commit()
+}
+
+func saveChangePoints(tx *sql.Tx, trs data.TestResults) error {
+	insCommit, err := tx.Prepare("INSERT OR IGNORE INTO commits (sha, position) VALUES (?, (SELECT COUNT(*) FROM commits))")
+	if err != nil {
+		return err
+	}
+	defer insCommit.Close()
+	delCp, err := tx.Prepare("DELETE FROM change_points WHERE project = ? AND test = ? AND configuration = ? AND sha = ?")
+	if err != nil {
+		return err
+	}
+	defer delCp.Close()
+	insCp, err := tx.Prepare("INSERT INTO change_points (project, test, configuration, sha, regression, category) VALUES (?, ?, ?, ?, ?, ?)")
+	if err != nil {
+		return err
+	}
+	defer insCp.Close()
+
+	for tr := range trs.All() {
+		project := tr.Project()
+		test := tr.Test()
+		configuration := tr.Configuration()
+		for _, c := range tr.Commits() {
+			if _, err := insCommit.Exec(c); err != nil {
+				return err
+			}
+			if _, err := delCp.Exec(project, test, configuration, c); err != nil {
+				return err
+			}
+		}
+		for _, cp := range tr.ChangePoints().All() {
+			t := cp.Type()
+			_, err := insCp.Exec(project, test, configuration, cp.Commit(), t.IsRegression(), int(t.Category()))
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// jsonColumn returns the JSON of a map with l elements, or an empty string for an empty map
+func jsonColumn(m interface{}, l int) (string, error) {
+	if l == 0 {
+		return "", nil
+	}
+	b, err := json.Marshal(m)
+	return string(b), err
+}
@@ -0,0 +1,256 @@
+package store
+
+import (
+	"database/sql"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/sealuzh/gopper/data"
+)
+
+func tempDB(t *testing.T) (string, func()) {
+	dir, err := ioutil.TempDir("", "gopper")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return filepath.Join(dir, "results.sqlite"), func() { os.RemoveAll(dir) }
+}
+
+func testResults(t *testing.T) data.TestResults {
+	trs := data.NewTestResults(data.DefaultHeading)
+	for i, c := range []string{"a1", "b2", "c3"} {
+		for _, v := range []float64{10, 11} {
+			err := trs.Add(&data.ExecutionResult{
+				Project:       "p",
+				Version:       "v" + c,
+				SHA:           c,
+				Configuration: "default",
+				Test:          "BenchmarkX",
+				RawVal:        v * float64(i+1),
+				Unit:          "ns/op",
+				Metrics:       map[string]float64{"B/op": v},
+				MetricUnits:   map[string]string{"B/op": "B/op"},
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+	return trs
+}
+
+func TestSQLiteRoundTrip(t *testing.T) {
+	p, cleanup := tempDB(t)
+	defer cleanup()
+
+	s, err := OpenSQLite(p)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer s.Close()
+
+	if err := s.Save(testResults(t)); err != nil {
+		t.Fatal(err)
+	}
+	// saving again replaces the executions
+	if err := s.Save(testResults(t)); err != nil {
+		t.Fatal(err)
+	}
+
+	commits, err := s.Commits()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(commits, []string{"a1", "b2", "c3"}) {
+		t.Errorf("Commits() = %v", commits)
+	}
+
+	trs, err := s.TestResults(Query{From: "b2"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	tr, ok := trs.Get(data.TestID("p", "BenchmarkX", "default"))
+	if !ok {
+		t.Fatalf("Test not loaded: %v", trs.TestNames())
+	}
+	if !reflect.DeepEqual(tr.Commits(), []string{"b2", "c3"}) {
+		t.Errorf("Commits of queried test = %v", tr.Commits())
+	}
+	ers, _ := tr.ExecutionResults("c3")
+	if !reflect.DeepEqual(ers.Values(), []float64{30, 33}) {
+		t.Errorf("Values = %v", ers.Values())
+	}
+	er := ers.All()[0]
+	if er.Unit != "ns/op" || er.Metrics["B/op"] != 10 || er.MetricUnits["B/op"] != "B/op" {
+		t.Errorf("Unit and metrics not restored: %+v", er)
+	}
+
+	if _, err := s.TestResults(Query{From: "unknown"}); err == nil {
+		t.Errorf("Expected an error for an unknown commit")
+	}
+}
+
+func TestSQLiteRoundTripTestProperties(t *testing.T) {
+	p, cleanup := tempDB(t)
+	defer cleanup()
+
+	s, err := OpenSQLite(p)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer s.Close()
+
+	id := data.TestID("p", "BenchmarkX", "default")
+	trs := testResults(t)
+	tr, ok := trs.Get(id)
+	if !ok {
+		t.Fatalf("Test not created: %v", trs.TestNames())
+	}
+	tr.SetUnit(data.Unit{Name: "ns/op", HigherIsBetter: true})
+	n := data.Normalisation{Method: data.NormalisationRatio, Baseline: "a1", Scale: 10}
+	tr.SetNormalisation(n)
+	ers, _ := tr.ExecutionResults("c3")
+	for _, er := range ers.All() {
+		er.Dispersion = true
+	}
+	if err := s.Save(trs); err != nil {
+		t.Fatal(err)
+	}
+
+	// a stored type differs from the type derived from the executions
+	cpType := data.NewDefaultChangePointType(true, data.Twenties)
+	cp, err := data.NewChangePointOfType("b2", tr, cpType)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := tr.AddChangePoint(cp); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.SaveChangePoints(trs); err != nil {
+		t.Fatal(err)
+	}
+
+	loaded, err := s.TestResults(Query{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	ltr, ok := loaded.Get(id)
+	if !ok {
+		t.Fatalf("Test not loaded: %v", loaded.TestNames())
+	}
+	if u := ltr.Unit(); u.Name != "ns/op" || !u.HigherIsBetter {
+		t.Errorf("Unit = %+v", u)
+	}
+	if ltr.Normalisation() != n {
+		t.Errorf("Normalisation = %+v, expected %+v", ltr.Normalisation(), n)
+	}
+	for _, c := range ltr.Commits() {
+		ers, _ := ltr.ExecutionResults(c)
+		for _, er := range ers.All() {
+			if er.Dispersion != (c == "c3") {
+				t.Errorf("Dispersion of '%s' = %v", c, er.Dispersion)
+			}
+		}
+	}
+	cps := ltr.ChangePoints().All()
+	if len(cps) != 1 || cps[0].Commit() != "b2" {
+		t.Fatalf("Change points = %v", cps)
+	}
+	if lt := cps[0].Type(); lt.IsRegression() != cpType.IsRegression() || lt.Category() != cpType.Category() {
+		t.Errorf("Change point type = %v, expected %v", lt, cpType)
+	}
+
+	// saving change points again replaces them
+	if err := s.SaveChangePoints(testResults(t)); err != nil {
+		t.Fatal(err)
+	}
+	loaded, err = s.TestResults(Query{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ltr, ok := loaded.Get(id); !ok || len(ltr.ChangePoints().All()) != 0 {
+		t.Errorf("Change points not replaced: %v", ltr.ChangePoints().All())
+	}
+}
+
+func TestSQLiteMigration(t *testing.T) {
+	p, cleanup := tempDB(t)
+	defer cleanup()
+
+	// schema of the first version without timestamp, unit and metrics
+	db, err := sql.Open(driver, p)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = db.Exec(`
+CREATE TABLE commits (sha TEXT PRIMARY KEY, position INTEGER NOT NULL);
+CREATE TABLE execution_results (
+	id INTEGER PRIMARY KEY AUTOINCREMENT,
+	project TEXT NOT NULL,
+	version TEXT NOT NULL,
+	sha TEXT NOT NULL REFERENCES commits (sha),
+	configuration TEXT NOT NULL,
+	test TEXT NOT NULL,
+	raw_val REAL NOT NULL
+);
+INSERT INTO commits (sha, position) VALUES ('a1', 0);
+INSERT INTO execution_results (project, version, sha, configuration, test, raw_val) VALUES ('p', 'v1', 'a1', 'default', 'BenchmarkX', 1.5);`)
+	db.Close()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	s, err := OpenSQLite(p)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer s.Close()
+
+	var version int
+	if err := s.db.QueryRow("PRAGMA user_version").Scan(&version); err != nil {
+		t.Fatal(err)
+	}
+	if version != schemaVersion {
+		t.Errorf("user_version = %d, expected %d", version, schemaVersion)
+	}
+
+	trs, err := s.TestResults(Query{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	tr, ok := trs.Get(data.TestID("p", "BenchmarkX", "default"))
+	if !ok {
+		t.Fatalf("Test not loaded: %v", trs.TestNames())
+	}
+	ers, _ := tr.ExecutionResults("a1")
+	if er := ers.All()[0]; er.RawVal != 1.5 || er.Unit != "" || er.Metrics != nil {
+		t.Errorf("Unexpected migrated execution: %+v", er)
+	}
+
+	if err := s.Save(testResults(t)); err != nil {
+		t.Errorf("Save after migration: %v", err)
+	}
+}
+
+func TestSQLiteNewerSchema(t *testing.T) {
+	p, cleanup := tempDB(t)
+	defer cleanup()
+
+	db, err := sql.Open(driver, p)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = db.Exec("PRAGMA user_version = 99")
+	db.Close()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if s, err := OpenSQLite(p); err == nil {
+		s.Close()
+		t.Errorf("Expected an error for a newer schema version")
+	}
+}
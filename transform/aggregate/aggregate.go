@@ -0,0 +1,127 @@
+package aggregate
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/montanaflynn/stats"
+	"github.com/sealuzh/gopper/data"
+)
+
+const (
+	Mean       = "mean"
+	Median     = "median"
+	Min        = "min"
+	Percentile = "percentile"
+)
+
+// names of the dispersion statistics of aggregated executions
+const (
+	dispersionStdDev = "stddev"
+	dispersionIQR    = "iqr"
+	dispersionMin    = "min"
+	dispersionMax    = "max"
+	dispersionCount  = "count"
+)
+
+var Statistics = [...]string{Mean, Median, Min, Percentile}
+
+// Aggregate replaces the executions of every version by a single execution whose value is the statistic (with
+// percentile p) of the values. With dispersion, the execution has the standard deviation, inter-quartile range,
+// minimum, maximum and number of the values as named metrics.
+func Aggregate(statistic string, p float64, dispersion bool) (data.TransFunc, error) {
+	var agg func(stats.Float64Data) (float64, error)
+	switch statistic {
+	case Mean:
+		agg = stats.Mean
+	case Median:
+		agg = stats.Median
+	case Min:
+		agg = stats.Min
+	case Percentile:
+		if p <= 0 || p > 100 {
+			return nil, fmt.Errorf("Percentile must be in (0, 100] (was %v)", p)
+		}
+		agg = func(d stats.Float64Data) (float64, error) {
+			// stats.Percentile is out of range for 100
+			if p == 100 {
+				return stats.Max(d)
+			}
+			return stats.Percentile(d, p)
+		}
+	default:
+		return nil, fmt.Errorf("Unknown statistic '%s'. Must be one of %v", statistic, Statistics)
+	}
+
+	return func(ctx context.Context, tests data.TestResult) (data.TestResult, error) {
+		var aggErr error
+		t, err := data.Derive(tests, tests.Commits(), func(commit string) []*data.ExecutionResult {
+			ers, ok := tests.ExecutionResults(commit)
+			if !ok || len(ers.All()) == 0 {
+				return nil
+			}
+			er, err := aggregate(ers, agg, dispersion)
+			if err != nil {
+				aggErr = fmt.Errorf("Commit '%s': %v", commit, err)
+				return nil
+			}
+			return []*data.ExecutionResult{er}
+		})
+		if err == nil {
+			err = aggErr
+		}
+		if err != nil {
+			return nil, fmt.Errorf("Could not aggregate: %v", err)
+		}
+		return t, nil
+	}, nil
+}
+
+// aggregate returns a synthetic execution with the fields of the first execution and the aggregated value
+func aggregate(ers data.ExecutionResults, agg func(stats.Float64Data) (float64, error), dispersion bool) (*data.ExecutionResult, error) {
+	vals := stats.Float64Data(ers.Values())
+	v, err := agg(vals)
+	if err != nil {
+		return nil, err
+	}
+
+	er := *ers.All()[0]
+	er.RawVal = v
+	er.Metrics = nil
+	er.MetricUnits = nil
+	er.Dispersion = false
+	if !dispersion {
+		return &er, nil
+	}
+
+	// the standard deviation of a single value is 0
+	sd := 0.0
+	if len(vals) > 1 {
+		sd, err = stats.StandardDeviationSample(vals)
+		if err != nil {
+			return nil, err
+		}
+	}
+	iqr, err := stats.InterQuartileRange(vals)
+	if err != nil {
+		// not defined for few values
+		iqr = 0
+	}
+	min, err := stats.Min(vals)
+	if err != nil {
+		return nil, err
+	}
+	max, err := stats.Max(vals)
+	if err != nil {
+		return nil, err
+	}
+	er.Metrics = map[string]float64{
+		dispersionStdDev: sd,
+		dispersionIQR:    iqr,
+		dispersionMin:    min,
+		dispersionMax:    max,
+		dispersionCount:  float64(len(vals)),
+	}
+	er.Dispersion = true
+	return &er, nil
+}
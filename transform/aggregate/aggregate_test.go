@@ -0,0 +1,90 @@
+package aggregate
+
+import (
+	"context"
+	"testing"
+
+	"github.com/sealuzh/gopper/data"
+)
+
+func testResult(t *testing.T, values ...float64) data.TestResult {
+	tr := data.NewTestResult("p", "a", "default")
+	for _, v := range values {
+		err := tr.AddExecutionResult(&data.ExecutionResult{Project: "p", Version: "v", SHA: "c1", Configuration: "default", Test: "a", RawVal: v, Metrics: map[string]float64{"B/op": v}})
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	return tr
+}
+
+// aggregated returns the single execution of commit c1 after aggregation
+func aggregated(t *testing.T, statistic string, p float64, dispersion bool, values ...float64) *data.ExecutionResult {
+	tf, err := Aggregate(statistic, p, dispersion)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tr, err := tf(context.Background(), testResult(t, values...))
+	if err != nil {
+		t.Fatal(err)
+	}
+	ers, ok := tr.ExecutionResults("c1")
+	if !ok || len(ers.All()) != 1 {
+		t.Fatalf("expected a single execution, got %v", ers)
+	}
+	return ers.All()[0]
+}
+
+func TestAggregate(t *testing.T) {
+	tests := []struct {
+		statistic string
+		p         float64
+		expected  float64
+	}{
+		{Mean, 0, 2.5},
+		{Median, 0, 2.5},
+		{Min, 0, 1},
+		{Percentile, 100, 4},
+	}
+	for _, test := range tests {
+		er := aggregated(t, test.statistic, test.p, false, 4, 1, 3, 2)
+		if er.RawVal != test.expected {
+			t.Errorf("%s: expected %v, got %v", test.statistic, test.expected, er.RawVal)
+		}
+		if er.Metrics != nil || er.Dispersion {
+			t.Errorf("%s: expected no metrics, got %v", test.statistic, er.Metrics)
+		}
+	}
+}
+
+func TestAggregateDispersion(t *testing.T) {
+	er := aggregated(t, Mean, 0, true, 1, 2, 3)
+	if !er.Dispersion {
+		t.Errorf("execution not marked as dispersion")
+	}
+	exp := map[string]float64{dispersionStdDev: 1, dispersionMin: 1, dispersionMax: 3, dispersionCount: 3}
+	for m, v := range exp {
+		if er.Metrics[m] != v {
+			t.Errorf("expected %s %v, got %v", m, v, er.Metrics[m])
+		}
+	}
+	if _, ok := er.Metrics["B/op"]; ok {
+		t.Errorf("named metric of the executions not removed: %v", er.Metrics)
+	}
+
+	// a single value has no deviation
+	if er := aggregated(t, Mean, 0, true, 5); er.Metrics[dispersionStdDev] != 0 {
+		t.Errorf("expected stddev 0 of a single value, got %v", er.Metrics[dispersionStdDev])
+	}
+}
+
+func TestAggregateInvalid(t *testing.T) {
+	for _, p := range []float64{0, 101} {
+		if _, err := Aggregate(Percentile, p, false); err == nil {
+			t.Errorf("expected an error for percentile %v", p)
+		}
+	}
+	if _, err := Aggregate("mode", 0, false); err == nil {
+		t.Errorf("expected an error for an unknown statistic")
+	}
+}
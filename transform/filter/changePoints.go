@@ -0,0 +1,54 @@
+package filter
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/sealuzh/gopper/data"
+)
+
+// HasChangePoints filters tests without change points
+func HasChangePoints() data.TransFunc {
+	return reasonPredicate(func(tests data.TestResult) (bool, string, error) {
+		return tests.ChangePoints().Len() > 0, "no change points", nil
+	})
+}
+
+// Regressions removes all change points that are not regressions
+func Regressions() data.TransFunc {
+	return ChangePoints(func(cp data.ChangePoint) bool {
+		return cp.Type().IsRegression()
+	})
+}
+
+// MinCategory removes all change points with a category below c
+func MinCategory(c data.ChangeCategory) data.TransFunc {
+	return ChangePoints(func(cp data.ChangePoint) bool {
+		return cp.Type().Category() >= c
+	})
+}
+
+// ChangePointsAt removes all change points that are not in commits
+func ChangePointsAt(commits []string) data.TransFunc {
+	cs := toSet(commits)
+	return ChangePoints(func(cp data.ChangePoint) bool {
+		_, ok := cs[cp.Commit()]
+		return ok
+	})
+}
+
+// ChangePoints removes the change points of every test for which keep is false. Tests without remaining change
+// points are filtered.
+func ChangePoints(keep func(data.ChangePoint) bool) data.TransFunc {
+	return func(ctx context.Context, tests data.TestResult) (data.TestResult, error) {
+		t, err := data.FilterChangePoints(tests, keep)
+		if err != nil {
+			return nil, fmt.Errorf("Could not filter change points: %v", err)
+		}
+		if t.ChangePoints().Len() == 0 {
+			data.FilterReason(ctx, "%d change points, none remaining", tests.ChangePoints().Len())
+			return nil, nil
+		}
+		return t, nil
+	}
+}
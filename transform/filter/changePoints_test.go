@@ -0,0 +1,75 @@
+package filter
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/sealuzh/gopper/data"
+)
+
+// changePointTest returns a test with a regression of 23 % at c2 and an improvement of 8 % at c3
+func changePointTest(t *testing.T) data.TestResult {
+	tr := data.NewTestResult("p", "a", "default")
+	for i, v := range []float64{10, 10, 13, 12} {
+		c := string(rune('1' + i))
+		err := tr.AddExecutionResult(&data.ExecutionResult{Project: "p", Version: "v" + c, SHA: "c" + c, Configuration: "default", Test: "a", RawVal: v})
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	for _, c := range []string{"c2", "c3"} {
+		cp, err := data.NewChangePoint(c, tr)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := tr.AddChangePoint(cp); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return tr
+}
+
+// changePointCommits returns the commits of the change points of the test after tf, nil if the test is filtered
+func changePointCommits(t *testing.T, tf data.TransFunc, tr data.TestResult) []string {
+	ret, err := tf(context.Background(), tr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ret == nil {
+		return nil
+	}
+	commits := []string{}
+	for _, cp := range ret.ChangePoints().All() {
+		commits = append(commits, cp.Commit())
+	}
+	return commits
+}
+
+func TestChangePoints(t *testing.T) {
+	tests := []struct {
+		name     string
+		tf       data.TransFunc
+		expected []string
+	}{
+		{"HasChangePoints", HasChangePoints(), []string{"c2", "c3"}},
+		{"Regressions", Regressions(), []string{"c2"}},
+		{"MinCategory 20", MinCategory(data.Twenties), []string{"c2"}},
+		{"MinCategory 30", MinCategory(data.Thirties), nil},
+		{"ChangePointsAt", ChangePointsAt([]string{"c3", "c4"}), []string{"c3"}},
+	}
+	for _, test := range tests {
+		if commits := changePointCommits(t, test.tf, changePointTest(t)); !reflect.DeepEqual(commits, test.expected) {
+			t.Errorf("%s: change points at %v, expected %v", test.name, commits, test.expected)
+		}
+	}
+
+	trs := testResults(t, []string{"a"}, []string{"default"})
+	tr, ok := trs.Get(data.TestID("p", "a", "default"))
+	if !ok {
+		t.Fatalf("Test not found: %v", trs.TestNames())
+	}
+	if commits := changePointCommits(t, HasChangePoints(), tr); commits != nil {
+		t.Errorf("Test without change points not filtered")
+	}
+}
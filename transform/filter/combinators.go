@@ -0,0 +1,65 @@
+package filter
+
+import (
+	"context"
+	"strings"
+
+	"github.com/sealuzh/gopper/data"
+)
+
+// And applies all fs in order and keeps the tests that none of them filters
+func And(fs ...data.TransFunc) data.TransFunc {
+	return func(ctx context.Context, tests data.TestResult) (data.TestResult, error) {
+		t := tests
+		for _, f := range fs {
+			var err error
+			t, err = f(ctx, t)
+			if err != nil || t == nil {
+				return nil, err
+			}
+		}
+		return t, nil
+	}
+}
+
+// Or keeps the tests that at least one of fs keeps. The test is transformed by the first of fs that keeps it. All fs
+// are applied to every test, hence nested name filters count all tests.
+func Or(fs ...data.TransFunc) data.TransFunc {
+	return func(ctx context.Context, tests data.TestResult) (data.TestResult, error) {
+		var kept data.TestResult
+		reasons := make([]string, 0, len(fs))
+		for _, f := range fs {
+			fctx, rs := data.WithFilterReasons(ctx)
+			t, err := f(fctx, tests)
+			if err != nil {
+				return nil, err
+			}
+			if t == nil {
+				reasons = append(reasons, rs.String())
+			} else if kept == nil {
+				kept = t
+			}
+		}
+		if kept != nil {
+			return kept, nil
+		}
+		data.FilterReason(ctx, "or(%s)", strings.Join(reasons, " | "))
+		return nil, nil
+	}
+}
+
+// Not keeps the (untransformed) tests that f filters
+func Not(f data.TransFunc) data.TransFunc {
+	return func(ctx context.Context, tests data.TestResult) (data.TestResult, error) {
+		fctx, _ := data.WithFilterReasons(ctx)
+		t, err := f(fctx, tests)
+		if err != nil {
+			return nil, err
+		}
+		if t != nil {
+			data.FilterReason(ctx, "not: kept by nested filters")
+			return nil, nil
+		}
+		return tests, nil
+	}
+}
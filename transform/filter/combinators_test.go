@@ -0,0 +1,56 @@
+package filter
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/sealuzh/gopper/data"
+)
+
+func TestCombinators(t *testing.T) {
+	trs := testResults(t, []string{"SortBench", "MapBench", "SortFlaky"}, []string{"default"})
+	bench, _, _ := Tests("includeTests", true, []string{"*Bench"})
+	sort, _, _ := Tests("includeTests", true, []string{"Sort*"})
+
+	tests := []struct {
+		name     string
+		tf       data.TransFunc
+		expected []string
+	}{
+		{"and", And(bench, sort), []string{"p/SortBench@default"}},
+		{"or", Or(bench, sort), []string{"p/MapBench@default", "p/SortBench@default", "p/SortFlaky@default"}},
+		{"not", Not(bench), []string{"p/SortFlaky@default"}},
+		{"not and", Not(And(bench, sort)), []string{"p/MapBench@default", "p/SortFlaky@default"}},
+	}
+	for _, test := range tests {
+		ret := data.Transform(context.Background(), trs, test.tf)
+		if names := sortedNames(ret); !reflect.DeepEqual(names, test.expected) {
+			t.Errorf("%s = %v, expected %v", test.name, names, test.expected)
+		}
+	}
+}
+
+func TestCombinatorsReason(t *testing.T) {
+	trs := testResults(t, []string{"MapBench"}, []string{"default"})
+	tr, ok := trs.Get(data.TestID("p", "MapBench", "default"))
+	if !ok {
+		t.Fatalf("Test not found: %v", trs.TestNames())
+	}
+
+	ctx, rs := data.WithFilterReasons(context.Background())
+	if ret, err := Or(MinVersions(2), MinMeanRuntime(10))(ctx, tr); err != nil || ret != nil {
+		t.Fatalf("or kept the test: %v, %v", ret, err)
+	}
+	if rs.String() == "" {
+		t.Errorf("No reason for or")
+	}
+
+	ctx, rs = data.WithFilterReasons(context.Background())
+	if ret, err := Not(MinVersions(1))(ctx, tr); err != nil || ret != nil {
+		t.Fatalf("not kept the test: %v, %v", ret, err)
+	}
+	if rs.String() != "not: kept by nested filters" {
+		t.Errorf("Reason of not = %q", rs.String())
+	}
+}
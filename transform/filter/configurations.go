@@ -0,0 +1,33 @@
+package filter
+
+import (
+	"fmt"
+
+	"github.com/sealuzh/gopper/data"
+)
+
+// Configurations filters tests whose configuration is not in include (if include is not empty) or is in exclude
+func Configurations(include, exclude []string) data.TransFunc {
+	inc := toSet(include)
+	exc := toSet(exclude)
+	return reasonPredicate(func(tests data.TestResult) (bool, string, error) {
+		c := tests.Configuration()
+		_, included := inc[c]
+		_, excluded := exc[c]
+		if excluded {
+			return false, fmt.Sprintf("configuration '%s' excluded", c), nil
+		}
+		if len(inc) != 0 && !included {
+			return false, fmt.Sprintf("configuration '%s' not included", c), nil
+		}
+		return true, "", nil
+	})
+}
+
+func toSet(s []string) map[string]struct{} {
+	ret := make(map[string]struct{}, len(s))
+	for _, e := range s {
+		ret[e] = struct{}{}
+	}
+	return ret
+}
@@ -0,0 +1,72 @@
+package filter
+
+import (
+	"context"
+	"reflect"
+	"sort"
+	"testing"
+
+	"github.com/sealuzh/gopper/data"
+)
+
+// testResults returns a test with one execution per test name and configuration
+func testResults(t *testing.T, tests []string, configurations []string) data.TestResults {
+	trs := data.NewTestResults(data.DefaultHeading)
+	for _, test := range tests {
+		for _, c := range configurations {
+			err := trs.Add(&data.ExecutionResult{Project: "p", Version: "v1", SHA: "c1", Configuration: c, Test: test, RawVal: 1})
+			if err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+	return trs
+}
+
+func sortedNames(trs data.TestResults) []string {
+	names := trs.TestNames()
+	sort.Strings(names)
+	return names
+}
+
+func TestConfigurations(t *testing.T) {
+	trs := testResults(t, []string{"a"}, []string{"jdk8", "jdk9", "jdk10"})
+	if trs.Len() != 3 {
+		t.Fatalf("Configurations are not separate tests: %v", trs.TestNames())
+	}
+
+	tests := []struct {
+		include  []string
+		exclude  []string
+		expected []string
+	}{
+		{nil, nil, []string{"p/a@jdk10", "p/a@jdk8", "p/a@jdk9"}},
+		{[]string{"jdk8", "jdk9"}, nil, []string{"p/a@jdk8", "p/a@jdk9"}},
+		{nil, []string{"jdk8"}, []string{"p/a@jdk10", "p/a@jdk9"}},
+		// exclude wins
+		{[]string{"jdk8", "jdk9"}, []string{"jdk9"}, []string{"p/a@jdk8"}},
+		{[]string{"jdk11"}, nil, []string{}},
+	}
+
+	for _, test := range tests {
+		ret := data.Transform(context.Background(), trs, Configurations(test.include, test.exclude))
+		if names := sortedNames(ret); !reflect.DeepEqual(names, test.expected) {
+			t.Errorf("Configurations(%v, %v) = %v, expected %v", test.include, test.exclude, names, test.expected)
+		}
+	}
+}
+
+func TestConfigurationsReason(t *testing.T) {
+	trs := testResults(t, []string{"a"}, []string{"jdk8", "jdk9"})
+	report := data.NewFilterReport()
+	ts := []data.Transformation{{Name: "configurations", Func: Configurations([]string{"jdk8"}, nil)}}
+	data.TransformReport(context.Background(), trs, ts, report)
+
+	ds := report.Decisions()
+	if len(ds) != 1 {
+		t.Fatalf("Decisions = %v", ds)
+	}
+	if ds[0].Project != "p" || ds[0].Test != "a" || ds[0].Configuration != "jdk9" || ds[0].Reason != "configuration 'jdk9' not included" {
+		t.Errorf("Decision = %+v", ds[0])
+	}
+}
@@ -1,7 +1,6 @@
 package filter
 
 import (
-	"context"
 	"fmt"
 
 	"github.com/montanaflynn/stats"
@@ -9,43 +8,26 @@ import (
 )
 
 func MinMeanRuntime(r float64) data.TransFunc {
-	return func(ctx context.Context, in <-chan data.TestResult) <-chan data.TestResult {
-		out := make(chan data.TestResult)
-		go func() {
-			defer close(out)
-			tests, ok := <-in
+	return reasonPredicate(func(tests data.TestResult) (bool, string, error) {
+		var avgRt float64
+		counter := 0
+		for _, c := range tests.Commits() {
+			ers, ok := tests.ExecutionResults(c)
 			if !ok {
-				return
+				return false, "", fmt.Errorf("Inconsistent test result: %s", c)
 			}
-			if tests == nil {
-				out <- nil
-				// fmt.Printf("MinMeanRuntime: in is nill\n")
-				return
+			m, err := stats.Mean(stats.Float64Data(ers.Values()))
+			if err != nil {
+				return false, "", fmt.Errorf("Mean of commit '%s': %v", c, err)
 			}
+			avgRt += m
+			counter++
+		}
+		avgRt = avgRt / float64(counter)
 
-			var avgRt float64
-			counter := 0
-			for _, c := range tests.Commits() {
-				ers, ok := tests.ExecutionResults(c)
-				if !ok {
-					panic(fmt.Sprintf("Inconsistent test result: %s", c))
-				}
-				m, err := stats.Mean(stats.Float64Data(ers.Values()))
-				if err != nil {
-					panic(err)
-				}
-				avgRt += m
-				counter++
-			}
-			avgRt = avgRt / float64(counter)
-
-			if avgRt < r {
-				out <- nil
-				// fmt.Printf("MinMeanRuntime: below avg runtime '%s': %v\n", tests.Test, avgRt)
-			} else {
-				out <- tests
-			}
-		}()
-		return out
-	}
+		if avgRt < r {
+			return false, fmt.Sprintf("mean %v < %v", avgRt, r), nil
+		}
+		return true, "", nil
+	})
 }
@@ -1,7 +1,6 @@
 package filter
 
 import (
-	"context"
 	"fmt"
 
 	"github.com/montanaflynn/stats"
@@ -9,47 +8,29 @@ import (
 )
 
 func MinMedianRuntime(r float64) data.TransFunc {
-	return func(ctx context.Context, in <-chan data.TestResult) <-chan data.TestResult {
-		out := make(chan data.TestResult)
-		go func() {
-			defer close(out)
-			tests, ok := <-in
+	return reasonPredicate(func(tests data.TestResult) (bool, string, error) {
+		commits := tests.Commits()
+		medians := make([]float64, len(commits))
+		for i, c := range commits {
+			ers, ok := tests.ExecutionResults(c)
 			if !ok {
-				return
+				return false, "", fmt.Errorf("Inconsistent test result: %s", c)
 			}
-
-			if tests == nil {
-				// fmt.Printf("MinMedianRuntime: in is nill\n")
-				out <- nil
-				return
-			}
-
-			commits := tests.Commits()
-			medians := make([]float64, len(commits))
-			for i, c := range commits {
-				ers, ok := tests.ExecutionResults(c)
-				if !ok {
-					panic(fmt.Sprintf("Inconsistent test result: %s", c))
-				}
-				median, err := stats.Median(stats.Float64Data(ers.Values()))
-				if err != nil {
-					panic(err)
-				}
-				medians[i] = median
-			}
-
-			median, err := stats.Median(stats.Float64Data(medians))
+			median, err := stats.Median(stats.Float64Data(ers.Values()))
 			if err != nil {
-				panic(err)
+				return false, "", fmt.Errorf("Median of commit '%s': %v", c, err)
 			}
+			medians[i] = median
+		}
 
-			if median < r {
-				out <- nil
-				// fmt.Printf("MinMedianRuntime: below median '%s': %v\n", tests.Test, median)
-			} else {
-				out <- tests
-			}
-		}()
-		return out
-	}
+		median, err := stats.Median(stats.Float64Data(medians))
+		if err != nil {
+			return false, "", fmt.Errorf("Median of commits: %v", err)
+		}
+
+		if median < r {
+			return false, fmt.Sprintf("median %v < %v", median, r), nil
+		}
+		return true, "", nil
+	})
 }
@@ -1,33 +1,17 @@
 package filter
 
 import (
-	"context"
+	"fmt"
 
 	"github.com/sealuzh/gopper/data"
 )
 
 func MinVersions(v int) data.TransFunc {
-	return func(ctx context.Context, in <-chan data.TestResult) <-chan data.TestResult {
-		out := make(chan data.TestResult)
-		go func() {
-			defer close(out)
-			tests, ok := <-in
-			if !ok {
-				return
-			}
-			if tests == nil {
-				out <- nil
-				// fmt.Printf("MinVersion: in is nill\n")
-				return
-			}
-			commits := tests.Commits()
-			if len(commits) >= v {
-				out <- tests
-			} else {
-				out <- nil
-				// fmt.Printf("MinVersion: too few results for '%s': %d\n", tests.Test, tests.Len())
-			}
-		}()
-		return out
-	}
+	return reasonPredicate(func(tests data.TestResult) (bool, string, error) {
+		commits := tests.Commits()
+		if len(commits) >= v {
+			return true, "", nil
+		}
+		return false, fmt.Sprintf("%d versions < %d", len(commits), v), nil
+	})
 }
@@ -0,0 +1,146 @@
+package filter
+
+import (
+	"bytes"
+	"fmt"
+	"regexp"
+	"strings"
+	"sync"
+
+	"github.com/sealuzh/gopper/data"
+)
+
+// prefix of patterns that are regular expressions instead of globs
+const regexPrefix = "re:"
+
+// Matches counts the tests that match every pattern of a name filter
+type Matches struct {
+	l        sync.Mutex
+	name     string
+	patterns []string
+	counts   []int
+}
+
+func (m *Matches) String() string {
+	m.l.Lock()
+	defer m.l.Unlock()
+	var b bytes.Buffer
+	fmt.Fprintf(&b, "%s:", m.name)
+	for i, p := range m.patterns {
+		fmt.Fprintf(&b, " '%s' %d", p, m.counts[i])
+		if i < len(m.patterns)-1 {
+			b.WriteString(",")
+		}
+	}
+	return b.String()
+}
+
+// add counts the matched patterns
+func (m *Matches) add(matched []bool) {
+	m.l.Lock()
+	defer m.l.Unlock()
+	for i, ok := range matched {
+		if ok {
+			m.counts[i]++
+		}
+	}
+}
+
+// Tests keeps (include) or drops (exclude) the tests whose name matches any of the patterns
+func Tests(name string, include bool, patterns []string) (data.TransFunc, *Matches, error) {
+	return names(name, include, patterns, func(t data.TestResult) string {
+		return t.Test()
+	})
+}
+
+// Projects keeps (include) or drops (exclude) the tests whose project matches any of the patterns
+func Projects(name string, include bool, patterns []string) (data.TransFunc, *Matches, error) {
+	return names(name, include, patterns, func(t data.TestResult) string {
+		return t.Project()
+	})
+}
+
+func names(name string, include bool, patterns []string, value func(data.TestResult) string) (data.TransFunc, *Matches, error) {
+	res := make([]*regexp.Regexp, len(patterns))
+	for i, p := range patterns {
+		re, err := Pattern(p)
+		if err != nil {
+			return nil, nil, err
+		}
+		res[i] = re
+	}
+
+	m := &Matches{
+		name:     name,
+		patterns: patterns,
+		counts:   make([]int, len(patterns)),
+	}
+	return reasonPredicate(func(tests data.TestResult) (bool, string, error) {
+		v := value(tests)
+		matched := make([]bool, len(res))
+		found := false
+		for i, re := range res {
+			matched[i] = re.MatchString(v)
+			found = found || matched[i]
+		}
+		m.add(matched)
+		if found == include {
+			return true, "", nil
+		}
+		if include {
+			return false, fmt.Sprintf("'%s' matches none of %v", v, patterns), nil
+		}
+		return false, fmt.Sprintf("'%s' matches %v", v, patterns), nil
+	}), m, nil
+}
+
+// Pattern compiles a glob (e.g. 'com.example.*Bench') or, with prefix 're:', a regular expression
+func Pattern(p string) (*regexp.Regexp, error) {
+	if strings.HasPrefix(p, regexPrefix) {
+		re, err := regexp.Compile(strings.TrimPrefix(p, regexPrefix))
+		if err != nil {
+			return nil, fmt.Errorf("Invalid regular expression '%s': %v", p, err)
+		}
+		return re, nil
+	}
+
+	// translate glob into an anchored regular expression
+	var b bytes.Buffer
+	b.WriteString("^")
+	inClass := false
+	classStart := false
+	for _, r := range p {
+		switch {
+		case classStart && r == '!':
+			// negated character class
+			classStart = false
+			b.WriteRune('^')
+		case inClass:
+			classStart = false
+			if r == ']' {
+				inClass = false
+			}
+			if r == '\\' {
+				b.WriteString(`\\`)
+				continue
+			}
+			b.WriteRune(r)
+		case r == '*':
+			b.WriteString(".*")
+		case r == '?':
+			b.WriteString(".")
+		case r == '[':
+			inClass = true
+			classStart = true
+			b.WriteRune(r)
+		default:
+			b.WriteString(regexp.QuoteMeta(string(r)))
+		}
+	}
+	b.WriteString("$")
+	re, err := regexp.Compile(b.String())
+	if err != nil {
+		return nil, fmt.Errorf("Invalid glob '%s': %v", p, err)
+	}
+	return re, nil
+}
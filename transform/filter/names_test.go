@@ -0,0 +1,102 @@
+package filter
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/sealuzh/gopper/data"
+)
+
+func TestPattern(t *testing.T) {
+	tests := []struct {
+		pattern string
+		matches []string
+		others  []string
+	}{
+		{"com.example.*Bench", []string{"com.example.SortBench", "com.example.a.b.Bench"}, []string{"comXexample.SortBench", "com.example.SortBench2"}},
+		{"Bench?", []string{"Bench1", "BenchA"}, []string{"Bench", "Bench12"}},
+		{"Bench[12]", []string{"Bench1", "Bench2"}, []string{"Bench3"}},
+		{"Bench[!12]", []string{"Bench3"}, []string{"Bench1"}},
+		{"Bench[a-c]", []string{"Benchb"}, []string{"Benchd"}},
+		// regular expression metacharacters are literal
+		{"sort(int)+", []string{"sort(int)+"}, []string{"sortint"}},
+		{`a\b`, []string{`a\b`}, []string{"ab"}},
+		// regular expressions are not anchored
+		{"re:Sort|Map", []string{"BenchSort", "MapBench"}, []string{"Bench"}},
+	}
+	for _, test := range tests {
+		re, err := Pattern(test.pattern)
+		if err != nil {
+			t.Errorf("Pattern(%q): %v", test.pattern, err)
+			continue
+		}
+		for _, m := range test.matches {
+			if !re.MatchString(m) {
+				t.Errorf("Pattern(%q) does not match %q", test.pattern, m)
+			}
+		}
+		for _, o := range test.others {
+			if re.MatchString(o) {
+				t.Errorf("Pattern(%q) matches %q", test.pattern, o)
+			}
+		}
+	}
+
+	for _, p := range []string{"re:(", "Bench[a-"} {
+		if _, err := Pattern(p); err == nil {
+			t.Errorf("Pattern(%q): expected an error", p)
+		}
+	}
+}
+
+func TestTests(t *testing.T) {
+	trs := testResults(t, []string{"SortBench", "MapBench", "Flaky"}, []string{"default"})
+
+	include, m, err := Tests("includeTests", true, []string{"*Bench", "Sort*"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	ret := data.Transform(context.Background(), trs, include)
+	if names := sortedNames(ret); !reflect.DeepEqual(names, []string{"p/MapBench@default", "p/SortBench@default"}) {
+		t.Errorf("include = %v", names)
+	}
+	if s := m.String(); s != "includeTests: '*Bench' 2, 'Sort*' 1" {
+		t.Errorf("Matches = %s", s)
+	}
+
+	exclude, _, err := Tests("excludeTests", false, []string{"Flaky"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	ret = data.Transform(context.Background(), trs, exclude)
+	if names := sortedNames(ret); !reflect.DeepEqual(names, []string{"p/MapBench@default", "p/SortBench@default"}) {
+		t.Errorf("exclude = %v", names)
+	}
+
+	projects, _, err := Projects("includeProjects", true, []string{"q"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ret := data.Transform(context.Background(), trs, projects); ret.Len() != 0 {
+		t.Errorf("includeProjects = %v", ret.TestNames())
+	}
+}
+
+func TestTestsMatchesInOr(t *testing.T) {
+	trs := testResults(t, []string{"SortBench", "MapBench", "Flaky"}, []string{"default"})
+	first, m1, _ := Tests("includeTests", true, []string{"*Bench"})
+	second, m2, _ := Tests("includeTests", true, []string{"Sort*", "Flaky"})
+
+	ret := data.Transform(context.Background(), trs, Or(first, second))
+	if ret.Len() != 3 {
+		t.Errorf("or = %v", ret.TestNames())
+	}
+	// the second filter counts the tests kept by the first one as well
+	if s := m1.String(); s != "includeTests: '*Bench' 2" {
+		t.Errorf("Matches of first = %s", s)
+	}
+	if s := m2.String(); s != "includeTests: 'Sort*' 1, 'Flaky' 1" {
+		t.Errorf("Matches of second = %s", s)
+	}
+}
@@ -0,0 +1,29 @@
+package filter
+
+import (
+	"fmt"
+
+	"github.com/sealuzh/gopper/data"
+)
+
+// BaseNames filters tests whose base name (i.e. name without parameters) is not in names
+func BaseNames(names []string) data.TransFunc {
+	ns := toSet(names)
+	return reasonPredicate(func(tests data.TestResult) (bool, string, error) {
+		_, ok := ns[tests.BaseName()]
+		return ok, fmt.Sprintf("base name '%s' not in %v", tests.BaseName(), names), nil
+	})
+}
+
+// Parameter filters tests without parameter key or whose value of the parameter is not in values
+func Parameter(key string, values []string) data.TransFunc {
+	vs := toSet(values)
+	return reasonPredicate(func(tests data.TestResult) (bool, string, error) {
+		v, ok := tests.Parameter(key)
+		if !ok {
+			return false, fmt.Sprintf("no parameter '%s'", key), nil
+		}
+		_, ok = vs[v]
+		return ok, fmt.Sprintf("parameter %s=%s not in %v", key, v, values), nil
+	})
+}
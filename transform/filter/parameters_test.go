@@ -0,0 +1,29 @@
+package filter
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/sealuzh/gopper/data"
+)
+
+func TestParameterFilters(t *testing.T) {
+	trs := testResults(t, []string{"Bench.sort[size=10, algo=quick]", "Bench.sort[size=1000, algo=merge]", "Bench.map", "Other[size=10]"}, []string{"default"})
+
+	tests := []struct {
+		name     string
+		f        data.TransFunc
+		expected []string
+	}{
+		{"base names", BaseNames([]string{"Bench.sort", "Bench.map"}), []string{"p/Bench.map@default", "p/Bench.sort[size=10, algo=quick]@default", "p/Bench.sort[size=1000, algo=merge]@default"}},
+		{"parameter", Parameter("size", []string{"10"}), []string{"p/Bench.sort[size=10, algo=quick]@default", "p/Other[size=10]@default"}},
+		{"parameter without match", Parameter("threads", []string{"1"}), []string{}},
+	}
+	for _, test := range tests {
+		ret := data.Transform(context.Background(), trs, test.f)
+		if names := sortedNames(ret); !reflect.DeepEqual(names, test.expected) {
+			t.Errorf("%s: %v, expected %v", test.name, names, test.expected)
+		}
+	}
+}
@@ -0,0 +1,39 @@
+package filter
+
+import (
+	"context"
+
+	"github.com/sealuzh/gopper/data"
+)
+
+// predicate returns a filter that keeps the tests for which keep is true
+func predicate(keep func(data.TestResult) bool) data.TransFunc {
+	return reasonPredicate(func(tests data.TestResult) (bool, string, error) {
+		return keep(tests), "", nil
+	})
+}
+
+// reasonPredicate returns a filter that keeps the tests for which keep is true. The reason returned by keep is
+// reported for filtered tests.
+func reasonPredicate(keep func(data.TestResult) (bool, string, error)) data.TransFunc {
+	return func(ctx context.Context, tests data.TestResult) (data.TestResult, error) {
+		ok, reason, err := keep(tests)
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			if reason != "" {
+				data.FilterReason(ctx, "%s", reason)
+			}
+			return nil, nil
+		}
+		return tests, nil
+	}
+}
+
+// None filters all tests with reason, e.g. if the transformations cannot be applied to the tests
+func None(reason string) data.TransFunc {
+	return reasonPredicate(func(tests data.TestResult) (bool, string, error) {
+		return false, reason, nil
+	})
+}
@@ -0,0 +1,134 @@
+package normalise
+
+import (
+	"context"
+	"fmt"
+	"math"
+
+	"github.com/montanaflynn/stats"
+	"github.com/sealuzh/gopper/data"
+)
+
+// Ratio normalises the values of every test relative to the mean of a baseline commit. Without baseline, the first
+// commit of a test is the baseline. Tests without the baseline are filtered.
+func Ratio(baseline string) data.TransFunc {
+	return normalise(func(tr data.TestResult) (data.Normalisation, error) {
+		b := baseline
+		if b == "" {
+			b = tr.Commits()[0]
+		}
+		ers, ok := tr.ExecutionResults(b)
+		if !ok {
+			return data.Normalisation{}, fmt.Errorf("Baseline commit '%s' not in test", b)
+		}
+		m, err := stats.Mean(stats.Float64Data(ers.Values()))
+		if err != nil {
+			return data.Normalisation{}, err
+		}
+		if m == 0 {
+			return data.Normalisation{}, fmt.Errorf("Mean of baseline commit '%s' is 0", b)
+		}
+		return data.Normalisation{
+			Method:   data.NormalisationRatio,
+			Baseline: b,
+			Scale:    m,
+		}, nil
+	}, func(n data.Normalisation, v float64) (float64, error) {
+		return v / n.Scale, nil
+	})
+}
+
+// ZScore normalises the values of every test by the mean and standard deviation of all its values
+func ZScore() data.TransFunc {
+	return normalise(func(tr data.TestResult) (data.Normalisation, error) {
+		vals := make([]float64, 0)
+		for _, c := range tr.Commits() {
+			ers, _ := tr.ExecutionResults(c)
+			vals = append(vals, ers.Values()...)
+		}
+		m, err := stats.Mean(stats.Float64Data(vals))
+		if err != nil {
+			return data.Normalisation{}, err
+		}
+		sd, err := stats.StandardDeviation(stats.Float64Data(vals))
+		if err != nil {
+			return data.Normalisation{}, err
+		}
+		if sd == 0 {
+			return data.Normalisation{}, fmt.Errorf("Standard deviation is 0")
+		}
+		return data.Normalisation{
+			Method: data.NormalisationZScore,
+			Scale:  sd,
+			Offset: m,
+		}, nil
+	}, func(n data.Normalisation, v float64) (float64, error) {
+		return (v - n.Offset) / n.Scale, nil
+	})
+}
+
+// Log replaces the values of every test by their natural logarithm. Tests with values <= 0 are filtered.
+func Log() data.TransFunc {
+	return normalise(func(tr data.TestResult) (data.Normalisation, error) {
+		return data.Normalisation{
+			Method: data.NormalisationLog,
+		}, nil
+	}, func(n data.Normalisation, v float64) (float64, error) {
+		if v <= 0 {
+			return 0, fmt.Errorf("Logarithm of %v", v)
+		}
+		return math.Log(v), nil
+	})
+}
+
+// normalise returns a transformation that determines the normalisation of every test with norm and replaces every
+// value with f. Named metrics are removed, as they are not normalised. Tests that are already normalised are filtered.
+func normalise(norm func(data.TestResult) (data.Normalisation, error), f func(data.Normalisation, float64) (float64, error)) data.TransFunc {
+	return func(ctx context.Context, tests data.TestResult) (data.TestResult, error) {
+		t, err := normaliseTest(tests, norm, f)
+		if err != nil {
+			return nil, fmt.Errorf("Could not normalise: %v", err)
+		}
+		return t, nil
+	}
+}
+
+func normaliseTest(tr data.TestResult, norm func(data.TestResult) (data.Normalisation, error), f func(data.Normalisation, float64) (float64, error)) (data.TestResult, error) {
+	if tr.Normalisation().IsNormalised() {
+		return nil, fmt.Errorf("Test is already normalised (%s)", tr.Normalisation().Method)
+	}
+	if len(tr.Commits()) == 0 {
+		return nil, fmt.Errorf("Test has no commits")
+	}
+	n, err := norm(tr)
+	if err != nil {
+		return nil, err
+	}
+
+	// change points of the normalised test are recreated with the normalisation
+	nt := tr.Copy()
+	nt.SetNormalisation(n)
+	var valErr error
+	ret, err := data.Derive(nt, tr.Commits(), func(commit string) []*data.ExecutionResult {
+		ers, _ := tr.ExecutionResults(commit)
+		ret := make([]*data.ExecutionResult, 0, len(ers.All()))
+		for _, er := range ers.All() {
+			v, err := f(n, er.RawVal)
+			if err != nil {
+				valErr = err
+				return nil
+			}
+			ner := *er
+			ner.RawVal = v
+			ner.Metrics = nil
+			ner.MetricUnits = nil
+			ner.Dispersion = false
+			ret = append(ret, &ner)
+		}
+		return ret
+	})
+	if err != nil {
+		return nil, err
+	}
+	return ret, valErr
+}
@@ -0,0 +1,111 @@
+package normalise
+
+import (
+	"context"
+	"math"
+	"reflect"
+	"testing"
+
+	"github.com/sealuzh/gopper/data"
+)
+
+// testResult returns a test with the values of commits c1, c2, ... (one slice of values per commit)
+func testResult(t *testing.T, values ...[]float64) data.TestResult {
+	tr := data.NewTestResult("p", "a", "default")
+	for i, vs := range values {
+		c := string(rune('1' + i))
+		for _, v := range vs {
+			err := tr.AddExecutionResult(&data.ExecutionResult{Project: "p", Version: "v", SHA: "c" + c, Configuration: "default", Test: "a", RawVal: v, Metrics: map[string]float64{"B/op": v}})
+			if err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+	return tr
+}
+
+func values(tr data.TestResult, commit string) []float64 {
+	ers, _ := tr.ExecutionResults(commit)
+	return ers.Values()
+}
+
+func TestRatio(t *testing.T) {
+	in := testResult(t, []float64{2, 4}, []float64{6, 10})
+
+	tr, err := Ratio("")(context.Background(), in)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if v := values(tr, "c2"); !reflect.DeepEqual(v, []float64{2, 10.0 / 3}) {
+		t.Errorf("Values of c2 = %v", v)
+	}
+	n := tr.Normalisation()
+	if n.Method != data.NormalisationRatio || n.Baseline != "c1" || n.Scale != 3 {
+		t.Errorf("Normalisation = %+v", n)
+	}
+	if ers, _ := tr.ExecutionResults("c1"); ers.All()[0].Metrics != nil {
+		t.Errorf("Named metrics not removed: %v", ers.All()[0].Metrics)
+	}
+
+	tr, err = Ratio("c2")(context.Background(), in)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if v := values(tr, "c1"); !reflect.DeepEqual(v, []float64{0.25, 0.5}) {
+		t.Errorf("Values of c1 relative to c2 = %v", v)
+	}
+
+	if _, err := Ratio("c9")(context.Background(), in); err == nil {
+		t.Errorf("Expected an error for a missing baseline")
+	}
+	if _, err := Ratio("")(context.Background(), testResult(t, []float64{0})); err == nil {
+		t.Errorf("Expected an error for a baseline mean of 0")
+	}
+}
+
+func TestZScore(t *testing.T) {
+	tr, err := ZScore()(context.Background(), testResult(t, []float64{1, 3}, []float64{5, 7}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	n := tr.Normalisation()
+	if n.Method != data.NormalisationZScore || n.Offset != 4 {
+		t.Errorf("Normalisation = %+v", n)
+	}
+	// the original values are restored
+	for c, orig := range map[string][]float64{"c1": {1, 3}, "c2": {5, 7}} {
+		for i, v := range values(tr, c) {
+			if o := n.Original(v); math.Abs(o-orig[i]) > 1e-9 {
+				t.Errorf("Original of %v = %v, expected %v", v, o, orig[i])
+			}
+		}
+	}
+
+	if _, err := ZScore()(context.Background(), testResult(t, []float64{2, 2})); err == nil {
+		t.Errorf("Expected an error for a standard deviation of 0")
+	}
+}
+
+func TestLog(t *testing.T) {
+	tr, err := Log()(context.Background(), testResult(t, []float64{1, math.E}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if v := values(tr, "c1"); !reflect.DeepEqual(v, []float64{0, 1}) {
+		t.Errorf("Values = %v", v)
+	}
+
+	if _, err := Log()(context.Background(), testResult(t, []float64{1, 0})); err == nil {
+		t.Errorf("Expected an error for a value of 0")
+	}
+}
+
+func TestNormalised(t *testing.T) {
+	tr, err := Log()(context.Background(), testResult(t, []float64{1}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Ratio("")(context.Background(), tr); err == nil {
+		t.Errorf("Expected an error for an already normalised test")
+	}
+}
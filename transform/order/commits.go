@@ -0,0 +1,124 @@
+package order
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/sealuzh/gopper/data"
+)
+
+// Commits orders the commits of every test by an explicit list of commits. Commits of the test results may be
+// abbreviated SHAs. Versions of commits that are not in the list are dropped with a warning (see ByCommits).
+// Abbreviations that match several commits are an error.
+func Commits(commits []string) Strategy {
+	return func(ctx context.Context, trs data.TestResults) (data.TestResults, error) {
+		ret, unknown, err := ByCommits(ctx, commits, trs)
+		if err != nil {
+			return nil, err
+		}
+		if len(unknown) > 0 {
+			fmt.Printf("WARN - %d commits are not in the commit order, their versions are dropped: %v\n", len(unknown), unknown)
+		}
+		return ret, nil
+	}
+}
+
+// ByCommits returns the test results with the commits that are in commits, in their order, and separately the sorted
+// commits of the test results that are not in commits. Tests without any known commit are dropped.
+func ByCommits(ctx context.Context, commits []string, trs data.TestResults) (data.TestResults, []string, error) {
+	r := newResolver(commits)
+	unknown := make(map[string]struct{})
+	ret, err := reorder(ctx, trs, func(tr data.TestResult) ([]string, error) {
+		cs := tr.Commits()
+		ordered := make([]string, 0, len(cs))
+		for _, c := range cs {
+			_, err := r.position(c)
+			switch {
+			case err == data.ErrUnknownCommit:
+				unknown[c] = struct{}{}
+			case err != nil:
+				return nil, fmt.Errorf("Could not order %s: %v", tr.ID(), err)
+			default:
+				ordered = append(ordered, c)
+			}
+		}
+		sort.Stable(byPosition{commits: ordered, r: r})
+		return ordered, nil
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	uc := make([]string, 0, len(unknown))
+	for c := range unknown {
+		uc = append(uc, c)
+	}
+	sort.Strings(uc)
+	return ret, uc, nil
+}
+
+// Git orders the commits by the first-parent history of a git repository (see Commits)
+func Git(infos []data.CommitInfo) Strategy {
+	commits := make([]string, len(infos))
+	for i, ci := range infos {
+		commits[i] = ci.SHA
+	}
+	return Commits(commits)
+}
+
+type resolver struct {
+	commits   []string
+	positions map[string]int
+	errs      map[string]error
+}
+
+func newResolver(commits []string) *resolver {
+	r := &resolver{
+		commits:   commits,
+		positions: make(map[string]int, len(commits)),
+		errs:      make(map[string]error),
+	}
+	for i, c := range commits {
+		r.positions[c] = i
+	}
+	return r
+}
+
+// position returns the position of a (possibly abbreviated) commit in the commit order
+func (r *resolver) position(sha string) (int, error) {
+	if p, ok := r.positions[sha]; ok {
+		return p, nil
+	}
+	if err, ok := r.errs[sha]; ok {
+		return -1, err
+	}
+
+	p, err := data.ResolveCommit(r.commits, sha)
+	if err != nil {
+		// cache unresolvable commits as well
+		r.errs[sha] = err
+		return -1, err
+	}
+	r.positions[sha] = p
+	return p, nil
+}
+
+type byPosition struct {
+	commits []string
+	r       *resolver
+}
+
+func (b byPosition) Len() int {
+	return len(b.commits)
+}
+
+func (b byPosition) Less(i, j int) bool {
+	pi, _ := b.r.position(b.commits[i])
+	pj, _ := b.r.position(b.commits[j])
+	return pi < pj
+}
+
+func (b byPosition) Swap(i, j int) {
+	b.commits[i], b.commits[j] = b.commits[j], b.commits[i]
+}
@@ -0,0 +1,103 @@
+package order
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/sealuzh/gopper/data"
+)
+
+// testResults returns test results with one execution per commit of every test
+func testResults(t *testing.T, tests map[string][]string) data.TestResults {
+	trs := data.NewTestResults(data.DefaultHeading)
+	for test, commits := range tests {
+		for i, c := range commits {
+			err := trs.Add(&data.ExecutionResult{Project: "p", Version: c, SHA: c, Configuration: "default", Test: test, RawVal: float64(i)})
+			if err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+	return trs
+}
+
+func commitsOf(t *testing.T, trs data.TestResults, test string) []string {
+	tr, ok := trs.Get(data.TestID("p", test, "default"))
+	if !ok {
+		t.Fatalf("Test %s not found in %v", test, trs.TestNames())
+	}
+	return tr.Commits()
+}
+
+func TestCommits(t *testing.T) {
+	order := []string{"aaaa1111", "bbbb2222", "cccc3333", "dddd4444"}
+	trs := testResults(t, map[string][]string{
+		"a": {"dddd4444", "aaaa1111", "cccc3333"},
+		// abbreviated SHAs
+		"b": {"cccc", "bbbb22"},
+	})
+
+	ret, err := Commits(order)(context.Background(), trs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if c := commitsOf(t, ret, "a"); !reflect.DeepEqual(c, []string{"aaaa1111", "cccc3333", "dddd4444"}) {
+		t.Errorf("Commits of a = %v", c)
+	}
+	if c := commitsOf(t, ret, "b"); !reflect.DeepEqual(c, []string{"bbbb22", "cccc"}) {
+		t.Errorf("Commits of b = %v", c)
+	}
+}
+
+func TestCommitsUnknown(t *testing.T) {
+	order := []string{"aaaa1111", "bbbb2222"}
+	trs := testResults(t, map[string][]string{
+		"a": {"bbbb2222", "ffff0000", "aaaa1111"},
+		"b": {"eeee0000"},
+	})
+
+	ret, unknown, err := ByCommits(context.Background(), order, trs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(unknown, []string{"eeee0000", "ffff0000"}) {
+		t.Errorf("Unknown commits = %v", unknown)
+	}
+	if c := commitsOf(t, ret, "a"); !reflect.DeepEqual(c, []string{"aaaa1111", "bbbb2222"}) {
+		t.Errorf("Commits of a = %v", c)
+	}
+	// tests without known commits are dropped
+	if names := ret.TestNames(); !reflect.DeepEqual(names, []string{"p/a@default"}) {
+		t.Errorf("TestNames = %v", names)
+	}
+
+	// the strategy drops unknown commits as well
+	ret, err = Commits(order)(context.Background(), trs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if c := commitsOf(t, ret, "a"); !reflect.DeepEqual(c, []string{"aaaa1111", "bbbb2222"}) {
+		t.Errorf("Commits of a = %v", c)
+	}
+}
+
+func TestCommitsAmbiguous(t *testing.T) {
+	order := []string{"aaaa1111", "aaaa2222"}
+	trs := testResults(t, map[string][]string{"a": {"aaaa"}})
+	if _, err := Commits(order)(context.Background(), trs); err == nil {
+		t.Errorf("Expected an error for an ambiguous commit")
+	}
+}
+
+func TestGit(t *testing.T) {
+	infos := []data.CommitInfo{{SHA: "aaaa1111"}, {SHA: "bbbb2222"}}
+	trs := testResults(t, map[string][]string{"a": {"bbbb2222", "aaaa"}})
+	ret, err := Git(infos)(context.Background(), trs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if c := commitsOf(t, ret, "a"); !reflect.DeepEqual(c, []string{"aaaa", "bbbb2222"}) {
+		t.Errorf("Commits = %v", c)
+	}
+}
@@ -0,0 +1,118 @@
+package order
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/sealuzh/gopper/data"
+)
+
+// Strategy reorders the commits of every test in test results
+type Strategy func(context.Context, data.TestResults) (data.TestResults, error)
+
+// Insertion keeps the order in which the commits were added, i.e. the order of the input
+func Insertion() Strategy {
+	return func(ctx context.Context, trs data.TestResults) (data.TestResults, error) {
+		return trs, nil
+	}
+}
+
+// Chain applies the strategies in order
+func Chain(ss ...Strategy) Strategy {
+	return func(ctx context.Context, trs data.TestResults) (data.TestResults, error) {
+		for _, s := range ss {
+			var err error
+			trs, err = s(ctx, trs)
+			if err != nil {
+				return nil, err
+			}
+		}
+		return trs, nil
+	}
+}
+
+// reorder reorders every test with the commits returned by f. Tests without any commit returned by f are dropped.
+func reorder(ctx context.Context, trs data.TestResults, f func(data.TestResult) ([]string, error)) (data.TestResults, error) {
+	ret := data.NewTestResults(trs.Heading())
+	for tr := range trs.All() {
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		default:
+		}
+
+		commits, err := f(tr)
+		if err != nil {
+			return nil, err
+		}
+		if len(commits) == 0 {
+			continue
+		}
+		ntr, err := data.Reorder(tr, commits)
+		if err != nil {
+			return nil, err
+		}
+		ret.AddTest(ntr)
+	}
+	return ret, nil
+}
+
+// sequenceTest is the test of the version sequence that Sequence orders
+const sequenceTest = "sequence"
+
+// Sequence returns the version sequence of all tests in the order of s. s orders a single test with one execution
+// (with the version and timestamp of the first execution) per version of trs, in the order of data.Commits.
+func Sequence(ctx context.Context, s Strategy, trs data.TestResults) ([]string, error) {
+	first := make(map[string]*data.ExecutionResult)
+	for tr := range trs.All() {
+		for _, c := range tr.Commits() {
+			if _, ok := first[c]; ok {
+				continue
+			}
+			ers, ok := tr.ExecutionResults(c)
+			if !ok || len(ers.All()) == 0 {
+				continue
+			}
+			er := *ers.All()[0]
+			er.Project, er.Test, er.Configuration = "", sequenceTest, ""
+			first[c] = &er
+		}
+	}
+
+	seq := data.NewTestResults(trs.Heading())
+	for _, c := range data.Commits(trs) {
+		er, ok := first[c]
+		if !ok {
+			continue
+		}
+		if err := seq.Add(er); err != nil {
+			return nil, err
+		}
+	}
+	ordered, err := s(ctx, seq)
+	if err != nil {
+		return nil, err
+	}
+	tr, ok := ordered.Get(data.TestID("", sequenceTest, ""))
+	if !ok {
+		return []string{}, nil
+	}
+	return tr.Commits(), nil
+}
+
+// Gaps marks the gaps of every test in the version sequence of s (see Sequence and data.Gaps)
+func Gaps(s Strategy) Strategy {
+	return func(ctx context.Context, trs data.TestResults) (data.TestResults, error) {
+		seq, err := Sequence(ctx, s, trs)
+		if err != nil {
+			return nil, fmt.Errorf("Could not order the version sequence: %v", err)
+		}
+		ret := data.NewTestResults(trs.Heading())
+		for tr := range trs.All() {
+			ntr := tr.Copy()
+			ntr.SetGaps(data.Gaps(tr, seq))
+			ret.AddTest(ntr)
+		}
+		return ret, nil
+	}
+}
@@ -0,0 +1,68 @@
+package order
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/sealuzh/gopper/data"
+)
+
+func TestSequence(t *testing.T) {
+	trs := testResults(t, map[string][]string{
+		"a": {"1.0", "3.0"},
+		"b": {"2.0", "3.0"},
+	})
+	seq, err := Sequence(context.Background(), SemVer(), trs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(seq, []string{"1.0", "2.0", "3.0"}) {
+		t.Errorf("Sequence = %v", seq)
+	}
+
+	// versions that are not in the commit order are not in the sequence
+	seq, err = Sequence(context.Background(), Commits([]string{"1.0", "3.0"}), trs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(seq, []string{"1.0", "3.0"}) {
+		t.Errorf("Sequence without unknown versions = %v", seq)
+	}
+}
+
+func gapsOf(t *testing.T, trs data.TestResults, test string) []data.Gap {
+	tr, ok := trs.Get(data.TestID("p", test, "default"))
+	if !ok {
+		t.Fatalf("Test %s not found in %v", test, trs.TestNames())
+	}
+	return tr.Gaps()
+}
+
+func TestGaps(t *testing.T) {
+	trs := testResults(t, map[string][]string{
+		"a": {"1.0", "3.0"},
+		"b": {"2.0", "3.0"},
+		"c": {"1.0", "2.0", "3.0"},
+	})
+	s := SemVer()
+	ret, err := Chain(s, Gaps(s))(context.Background(), trs)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []data.Gap{{Before: "1.0", After: "3.0", Missing: []string{"2.0"}}}
+	if g := gapsOf(t, ret, "a"); !reflect.DeepEqual(g, expected) {
+		t.Errorf("Gaps of a = %v, expected %v", g, expected)
+	}
+	// versions before the first version of a test are not gaps
+	for _, test := range []string{"b", "c"} {
+		if g := gapsOf(t, ret, test); len(g) != 0 {
+			t.Errorf("Gaps of %s = %v", test, g)
+		}
+	}
+	// the input is not changed
+	if g := gapsOf(t, trs, "a"); len(g) != 0 {
+		t.Errorf("Gaps marked on the input: %v", g)
+	}
+}
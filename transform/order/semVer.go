@@ -0,0 +1,151 @@
+package order
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/sealuzh/gopper/data"
+)
+
+// SemVer orders the commits of every test by the semantic version of their executions' Version.
+// Commits without a valid semantic version are put after all others and reported.
+func SemVer() Strategy {
+	return func(ctx context.Context, trs data.TestResults) (data.TestResults, error) {
+		invalid := make(map[string]struct{})
+		ret, err := reorder(ctx, trs, func(tr data.TestResult) ([]string, error) {
+			commits := tr.Commits()
+			vs := make([]*semVer, len(commits))
+			for i, c := range commits {
+				ers, ok := tr.ExecutionResults(c)
+				if !ok || len(ers.All()) == 0 {
+					return nil, fmt.Errorf("Inconsistent test result: %s @ %s", tr.ID(), c)
+				}
+				v := ers.All()[0].Version
+				sv, ok := parseSemVer(v)
+				if !ok {
+					invalid[v] = struct{}{}
+				}
+				vs[i] = sv
+			}
+			sort.Stable(bySemVer{commits: commits, versions: vs})
+			return commits, nil
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		if len(invalid) > 0 {
+			iv := make([]string, 0, len(invalid))
+			for v := range invalid {
+				iv = append(iv, v)
+			}
+			sort.Strings(iv)
+			fmt.Printf("WARN - %d versions are not semantic versions and ordered last: %v\n", len(iv), iv)
+		}
+		return ret, nil
+	}
+}
+
+type semVer struct {
+	core       [3]int
+	preRelease []string
+}
+
+// parseSemVer parses versions of the form [v]MAJOR[.MINOR[.PATCH]][-PRERELEASE][+BUILD]
+func parseSemVer(v string) (*semVer, bool) {
+	v = strings.TrimPrefix(strings.TrimSpace(v), "v")
+	if i := strings.Index(v, "+"); i != -1 {
+		v = v[:i]
+	}
+	var pre string
+	if i := strings.Index(v, "-"); i != -1 {
+		pre = v[i+1:]
+		v = v[:i]
+	}
+
+	parts := strings.Split(v, ".")
+	if len(parts) > 3 {
+		return nil, false
+	}
+	sv := &semVer{}
+	for i, p := range parts {
+		n, err := strconv.Atoi(p)
+		if err != nil || n < 0 {
+			return nil, false
+		}
+		sv.core[i] = n
+	}
+	if pre != "" {
+		sv.preRelease = strings.Split(pre, ".")
+	}
+	return sv, true
+}
+
+// compare returns -1, 0 or 1 if v is lower, equal or higher than o according to the semantic version precedence
+func (v *semVer) compare(o *semVer) int {
+	for i := range v.core {
+		if v.core[i] != o.core[i] {
+			return compareInt(v.core[i], o.core[i])
+		}
+	}
+
+	// a pre-release has a lower precedence than the release
+	lv, lo := len(v.preRelease), len(o.preRelease)
+	if lv == 0 || lo == 0 {
+		return compareInt(lo, lv)
+	}
+	for i := 0; i < lv && i < lo; i++ {
+		iv, errv := strconv.Atoi(v.preRelease[i])
+		io, erro := strconv.Atoi(o.preRelease[i])
+		switch {
+		case errv == nil && erro == nil:
+			if iv != io {
+				return compareInt(iv, io)
+			}
+		case errv == nil:
+			// numeric identifiers have a lower precedence
+			return -1
+		case erro == nil:
+			return 1
+		default:
+			if c := strings.Compare(v.preRelease[i], o.preRelease[i]); c != 0 {
+				return c
+			}
+		}
+	}
+	return compareInt(lv, lo)
+}
+
+func compareInt(a, b int) int {
+	if a < b {
+		return -1
+	} else if a > b {
+		return 1
+	}
+	return 0
+}
+
+type bySemVer struct {
+	commits  []string
+	versions []*semVer
+}
+
+func (b bySemVer) Len() int {
+	return len(b.commits)
+}
+
+func (b bySemVer) Less(i, j int) bool {
+	vi, vj := b.versions[i], b.versions[j]
+	if vi == nil || vj == nil {
+		return vi != nil && vj == nil
+	}
+	return vi.compare(vj) < 0
+}
+
+func (b bySemVer) Swap(i, j int) {
+	b.commits[i], b.commits[j] = b.commits[j], b.commits[i]
+	b.versions[i], b.versions[j] = b.versions[j], b.versions[i]
+}
@@ -0,0 +1,81 @@
+package order
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/sealuzh/gopper/data"
+)
+
+func TestParseSemVer(t *testing.T) {
+	tests := []struct {
+		v     string
+		core  [3]int
+		pre   []string
+		valid bool
+	}{
+		{"1.2.3", [3]int{1, 2, 3}, nil, true},
+		{"v1.2", [3]int{1, 2, 0}, nil, true},
+		{"2", [3]int{2, 0, 0}, nil, true},
+		{"1.0.0-rc.1+build.5", [3]int{1, 0, 0}, []string{"rc", "1"}, true},
+		{"1.2.3.4", [3]int{}, nil, false},
+		{"1.x", [3]int{}, nil, false},
+		{"", [3]int{}, nil, false},
+		{"abcdef", [3]int{}, nil, false},
+	}
+
+	for _, test := range tests {
+		sv, ok := parseSemVer(test.v)
+		if ok != test.valid {
+			t.Errorf("parseSemVer(%q) valid = %v, expected %v", test.v, ok, test.valid)
+			continue
+		}
+		if !ok {
+			continue
+		}
+		if sv.core != test.core || !reflect.DeepEqual(sv.preRelease, test.pre) {
+			t.Errorf("parseSemVer(%q) = %+v", test.v, sv)
+		}
+	}
+}
+
+func TestSemVerCompare(t *testing.T) {
+	// ascending precedence (semver.org, section 11)
+	versions := []string{"1.0.0-alpha", "1.0.0-alpha.1", "1.0.0-alpha.beta", "1.0.0-beta", "1.0.0-beta.2", "1.0.0-beta.11", "1.0.0-rc.1", "1.0.0", "1.0.1", "1.1.0", "2.0.0"}
+	for i := range versions {
+		for j := range versions {
+			vi, _ := parseSemVer(versions[i])
+			vj, _ := parseSemVer(versions[j])
+			expected := compareInt(i, j)
+			if c := vi.compare(vj); c != expected {
+				t.Errorf("compare(%s, %s) = %d, expected %d", versions[i], versions[j], c, expected)
+			}
+		}
+	}
+
+	a, _ := parseSemVer("v1.0.0+build.1")
+	b, _ := parseSemVer("1.0.0+build.2")
+	if c := a.compare(b); c != 0 {
+		t.Errorf("Build metadata is part of the precedence: %d", c)
+	}
+}
+
+func TestSemVer(t *testing.T) {
+	trs := data.NewTestResults(data.DefaultHeading)
+	for _, v := range [][2]string{{"c1", "v1.10.0"}, {"c2", "snapshot"}, {"c3", "v1.2.0"}, {"c4", "v1.2.0-rc.1"}} {
+		err := trs.Add(&data.ExecutionResult{Project: "p", Version: v[1], SHA: v[0], Configuration: "default", Test: "a", RawVal: 1})
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	ret, err := SemVer()(context.Background(), trs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// invalid versions last
+	if c := commitsOf(t, ret, "a"); !reflect.DeepEqual(c, []string{"c4", "c3", "c1", "c2"}) {
+		t.Errorf("Commits = %v", c)
+	}
+}
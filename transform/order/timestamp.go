@@ -0,0 +1,75 @@
+package order
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/sealuzh/gopper/data"
+)
+
+// layouts of timestamps if no layout is provided, besides unix timestamps in seconds
+var defaultLayouts = []string{time.RFC3339Nano, "2006-01-02 15:04:05", "2006-01-02"}
+
+// Timestamp orders the commits of every test by the earliest Timestamp of their executions. The timestamps are parsed
+// with layout, or if empty, as RFC 3339, "2006-01-02 15:04:05", "2006-01-02" or unix timestamp in seconds.
+func Timestamp(layout string) Strategy {
+	return func(ctx context.Context, trs data.TestResults) (data.TestResults, error) {
+		return reorder(ctx, trs, func(tr data.TestResult) ([]string, error) {
+			commits := tr.Commits()
+			ts := make([]time.Time, len(commits))
+			for i, c := range commits {
+				ers, ok := tr.ExecutionResults(c)
+				if !ok {
+					return nil, fmt.Errorf("Inconsistent test result: %s @ %s", tr.ID(), c)
+				}
+				for j, er := range ers.All() {
+					t, err := parseTimestamp(er.Timestamp, layout)
+					if err != nil {
+						return nil, fmt.Errorf("Invalid timestamp of %s @ %s: %v", tr.ID(), c, err)
+					}
+					if j == 0 || t.Before(ts[i]) {
+						ts[i] = t
+					}
+				}
+			}
+			sort.Stable(byTime{commits: commits, times: ts})
+			return commits, nil
+		})
+	}
+}
+
+func parseTimestamp(s, layout string) (time.Time, error) {
+	if layout != "" {
+		return time.Parse(layout, s)
+	}
+	for _, l := range defaultLayouts {
+		if t, err := time.Parse(l, s); err == nil {
+			return t, nil
+		}
+	}
+	if sec, err := strconv.ParseFloat(s, 64); err == nil {
+		return time.Unix(0, int64(sec*float64(time.Second))), nil
+	}
+	return time.Time{}, fmt.Errorf("Unknown timestamp format '%s'", s)
+}
+
+type byTime struct {
+	commits []string
+	times   []time.Time
+}
+
+func (b byTime) Len() int {
+	return len(b.commits)
+}
+
+func (b byTime) Less(i, j int) bool {
+	return b.times[i].Before(b.times[j])
+}
+
+func (b byTime) Swap(i, j int) {
+	b.commits[i], b.commits[j] = b.commits[j], b.commits[i]
+	b.times[i], b.times[j] = b.times[j], b.times[i]
+}
@@ -0,0 +1,57 @@
+package order
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/sealuzh/gopper/data"
+)
+
+func TestParseTimestamp(t *testing.T) {
+	expected := time.Date(2017, 3, 1, 12, 30, 0, 0, time.UTC)
+	tests := []struct {
+		s      string
+		layout string
+	}{
+		{"2017-03-01T12:30:00Z", ""},
+		{"2017-03-01 12:30:00", ""},
+		{"1488371400", ""},
+		{"01.03.2017 12:30", "02.01.2006 15:04"},
+	}
+
+	for _, test := range tests {
+		ts, err := parseTimestamp(test.s, test.layout)
+		if err != nil {
+			t.Errorf("parseTimestamp(%q, %q): %v", test.s, test.layout, err)
+			continue
+		}
+		if !ts.Equal(expected) {
+			t.Errorf("parseTimestamp(%q, %q) = %v, expected %v", test.s, test.layout, ts, expected)
+		}
+	}
+
+	if _, err := parseTimestamp("yesterday", ""); err == nil {
+		t.Errorf("Expected an error for an unknown format")
+	}
+}
+
+func TestTimestamp(t *testing.T) {
+	trs := data.NewTestResults(data.DefaultHeading)
+	for _, er := range [][2]string{{"c1", "2017-03-03"}, {"c2", "2017-03-01"}, {"c3", "2017-03-04"}, {"c1", "2017-03-02"}} {
+		err := trs.Add(&data.ExecutionResult{Project: "p", Version: er[0], SHA: er[0], Configuration: "default", Test: "a", RawVal: 1, Timestamp: er[1]})
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	ret, err := Timestamp("")(context.Background(), trs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// ordered by the earliest execution of a commit
+	if c := commitsOf(t, ret, "a"); !reflect.DeepEqual(c, []string{"c2", "c1", "c3"}) {
+		t.Errorf("Commits = %v", c)
+	}
+}
@@ -0,0 +1,93 @@
+package sample
+
+import (
+	"context"
+	"fmt"
+	"hash/fnv"
+	"math/rand"
+	"sort"
+
+	"github.com/sealuzh/gopper/data"
+)
+
+// MinExecutions removes the versions with fewer than n (at least 1) executions from every test. Tests without
+// remaining versions are filtered.
+func MinExecutions(n int) (data.TransFunc, error) {
+	if n < 1 {
+		return nil, fmt.Errorf("Minimum number of executions must be at least 1, was %d", n)
+	}
+	return executions(func(tr data.TestResult, commit string, ers []*data.ExecutionResult) []*data.ExecutionResult {
+		if len(ers) < n {
+			return nil
+		}
+		return ers
+	}), nil
+}
+
+// Cap keeps the first m (at least 1) executions of every version
+func Cap(m int) (data.TransFunc, error) {
+	if err := checkMax(m); err != nil {
+		return nil, err
+	}
+	return executions(func(tr data.TestResult, commit string, ers []*data.ExecutionResult) []*data.ExecutionResult {
+		if len(ers) <= m {
+			return ers
+		}
+		return ers[:m]
+	}), nil
+}
+
+// Subsample keeps m (at least 1) randomly selected executions of every version, in their original order. The
+// selection only depends on seed, the test and the version, hence it is reproducible.
+func Subsample(m int, seed int64) (data.TransFunc, error) {
+	if err := checkMax(m); err != nil {
+		return nil, err
+	}
+	return executions(func(tr data.TestResult, commit string, ers []*data.ExecutionResult) []*data.ExecutionResult {
+		if len(ers) <= m {
+			return ers
+		}
+		h := fnv.New64a()
+		h.Write([]byte(tr.ID()))
+		h.Write([]byte{0})
+		h.Write([]byte(commit))
+		r := rand.New(rand.NewSource(seed ^ int64(h.Sum64())))
+
+		idx := r.Perm(len(ers))[:m]
+		sort.Ints(idx)
+		ret := make([]*data.ExecutionResult, m)
+		for i, j := range idx {
+			ret[i] = ers[j]
+		}
+		return ret
+	}), nil
+}
+
+func checkMax(m int) error {
+	if m < 1 {
+		return fmt.Errorf("Maximum number of executions must be at least 1, was %d", m)
+	}
+	return nil
+}
+
+// executions returns a transformation that replaces the executions of every version of a test by the executions
+// returned by f. Versions without executions are removed, tests without versions are filtered.
+func executions(f func(tr data.TestResult, commit string, ers []*data.ExecutionResult) []*data.ExecutionResult) data.TransFunc {
+	return func(ctx context.Context, tests data.TestResult) (data.TestResult, error) {
+		t, err := data.Derive(tests, tests.Commits(), func(commit string) []*data.ExecutionResult {
+			ers, ok := tests.ExecutionResults(commit)
+			if !ok {
+				return nil
+			}
+			return f(tests, commit, ers.All())
+		})
+		if err != nil {
+			return nil, fmt.Errorf("Could not sample: %v", err)
+		}
+		if len(t.Commits()) == 0 {
+			data.FilterReason(ctx, "no versions remaining of %d", len(tests.Commits()))
+			return nil, nil
+		}
+		return t, nil
+	}
+}
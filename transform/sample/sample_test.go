@@ -0,0 +1,109 @@
+package sample
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/sealuzh/gopper/data"
+)
+
+// testResult returns a test with the executions 0, 1, ... of every version in executions (commit -> count)
+func testResult(t *testing.T, executions map[string]int) data.TestResult {
+	tr := data.NewTestResult("p", "a", "default")
+	for c, n := range executions {
+		for i := 0; i < n; i++ {
+			err := tr.AddExecutionResult(&data.ExecutionResult{Project: "p", Version: c, SHA: c, Configuration: "default", Test: "a", RawVal: float64(i)})
+			if err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+	return tr
+}
+
+func values(t *testing.T, tr data.TestResult, commit string) []float64 {
+	ers, ok := tr.ExecutionResults(commit)
+	if !ok {
+		return nil
+	}
+	return ers.Values()
+}
+
+func TestMinExecutions(t *testing.T) {
+	tf, err := MinExecutions(3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tr, err := tf(context.Background(), testResult(t, map[string]int{"c1": 2, "c2": 3}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(tr.Commits(), []string{"c2"}) {
+		t.Errorf("Commits = %v", tr.Commits())
+	}
+
+	tr, err = tf(context.Background(), testResult(t, map[string]int{"c1": 2}))
+	if err != nil || tr != nil {
+		t.Errorf("Test without versions not filtered: %v, %v", tr, err)
+	}
+
+	if _, err := MinExecutions(0); err == nil {
+		t.Errorf("Expected an error for 0 executions")
+	}
+}
+
+func TestCap(t *testing.T) {
+	tf, err := Cap(2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tr, err := tf(context.Background(), testResult(t, map[string]int{"c1": 1, "c2": 4}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if v := values(t, tr, "c1"); !reflect.DeepEqual(v, []float64{0}) {
+		t.Errorf("Values of c1 = %v", v)
+	}
+	if v := values(t, tr, "c2"); !reflect.DeepEqual(v, []float64{0, 1}) {
+		t.Errorf("Values of c2 = %v", v)
+	}
+
+	for _, m := range []int{0, -1} {
+		if _, err := Cap(m); err == nil {
+			t.Errorf("Cap(%d): expected an error", m)
+		}
+	}
+}
+
+func TestSubsample(t *testing.T) {
+	in := testResult(t, map[string]int{"c1": 10})
+	sampled := func(seed int64) []float64 {
+		tf, err := Subsample(3, seed)
+		if err != nil {
+			t.Fatal(err)
+		}
+		tr, err := tf(context.Background(), in)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return values(t, tr, "c1")
+	}
+
+	v := sampled(42)
+	if len(v) != 3 {
+		t.Fatalf("Values = %v, expected 3", v)
+	}
+	for i := 1; i < len(v); i++ {
+		if v[i-1] >= v[i] {
+			t.Errorf("Values not in their original order: %v", v)
+		}
+	}
+	if again := sampled(42); !reflect.DeepEqual(v, again) {
+		t.Errorf("Selection not reproducible: %v and %v", v, again)
+	}
+
+	if _, err := Subsample(-1, 42); err == nil {
+		t.Errorf("Expected an error for -1 executions")
+	}
+}
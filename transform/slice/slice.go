@@ -0,0 +1,114 @@
+package slice
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/sealuzh/gopper/data"
+)
+
+// Commits returns a transformation that trims every test to the commits for which keep is true. Tests without
+// remaining commits are filtered.
+func Commits(keep func(commit string) bool) data.TransFunc {
+	return func(ctx context.Context, tests data.TestResult) (data.TestResult, error) {
+		commits := tests.Commits()
+		kept := make([]string, 0, len(commits))
+		for _, c := range commits {
+			if keep(c) {
+				kept = append(kept, c)
+			}
+		}
+		switch len(kept) {
+		case 0:
+			data.FilterReason(ctx, "no versions remaining of %d", len(commits))
+			return nil, nil
+		case len(commits):
+			return tests, nil
+		}
+		t, err := data.Reorder(tests, kept)
+		if err != nil {
+			return nil, fmt.Errorf("Could not trim: %v", err)
+		}
+		return t, nil
+	}
+}
+
+// Range trims every test to the commits between from and to of the commit sequence seq (see RangeCommits)
+func Range(seq []string, from, to string, fromInclusive, toInclusive bool) (data.TransFunc, error) {
+	commits, err := RangeCommits(seq, from, to, fromInclusive, toInclusive)
+	if err != nil {
+		return nil, err
+	}
+	return Commits(inSet(commits)), nil
+}
+
+// RangeCommits returns the commits between from and to of the commit sequence seq. Empty bounds are open, the
+// bounds themselves are included if inclusive. Bounds may be abbreviated SHAs (see data.ResolveCommit).
+func RangeCommits(seq []string, from, to string, fromInclusive, toInclusive bool) ([]string, error) {
+	start := 0
+	if from != "" {
+		i, err := data.ResolveCommit(seq, from)
+		if err != nil {
+			return nil, fmt.Errorf("Could not resolve commit '%s': %v", from, err)
+		}
+		start = i
+		if !fromInclusive {
+			start++
+		}
+	}
+	end := len(seq) - 1
+	if to != "" {
+		i, err := data.ResolveCommit(seq, to)
+		if err != nil {
+			return nil, fmt.Errorf("Could not resolve commit '%s': %v", to, err)
+		}
+		end = i
+		if !toInclusive {
+			end--
+		}
+	}
+	if start > end {
+		return nil, fmt.Errorf("Commit range '%s' - '%s' is empty", from, to)
+	}
+	return seq[start : end+1], nil
+}
+
+// Last trims every test to the last n (at least 1) commits of the commit sequence seq
+func Last(seq []string, n int) (data.TransFunc, error) {
+	if n < 1 {
+		return nil, fmt.Errorf("Number of last versions must be at least 1, was %d", n)
+	}
+	start := len(seq) - n
+	if start < 0 {
+		start = 0
+	}
+	return Commits(inSet(seq[start:])), nil
+}
+
+// Exclude removes the commits (e.g. broken builds) from every test. Commits may be abbreviated SHAs.
+func Exclude(seq []string, commits []string) data.TransFunc {
+	excluded := make([]string, 0, len(commits))
+	for _, c := range commits {
+		i, err := data.ResolveCommit(seq, c)
+		if err != nil {
+			fmt.Printf("WARN - Excluded commit '%s' not resolved: %v\n", c, err)
+			continue
+		}
+		excluded = append(excluded, seq[i])
+	}
+	in := inSet(excluded)
+	return Commits(func(c string) bool {
+		return !in(c)
+	})
+}
+
+func inSet(commits []string) func(string) bool {
+	set := make(map[string]struct{}, len(commits))
+	for _, c := range commits {
+		set[c] = struct{}{}
+	}
+	return func(c string) bool {
+		_, ok := set[c]
+		return ok
+	}
+}
@@ -0,0 +1,108 @@
+package slice
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/sealuzh/gopper/data"
+)
+
+var seq = []string{"aaaa1111", "bbbb2222", "cccc3333", "dddd4444"}
+
+func testResult(t *testing.T) data.TestResult {
+	tr := data.NewTestResult("p", "a", "default")
+	for i, c := range seq {
+		err := tr.AddExecutionResult(&data.ExecutionResult{Project: "p", Version: c, SHA: c, Configuration: "default", Test: "a", RawVal: float64(i)})
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	return tr
+}
+
+// commitsAfter returns the commits of the test after tf, nil if the test is filtered
+func commitsAfter(t *testing.T, tf data.TransFunc) []string {
+	tr, err := tf(context.Background(), testResult(t))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if tr == nil {
+		return nil
+	}
+	return tr.Commits()
+}
+
+func TestRangeCommits(t *testing.T) {
+	tests := []struct {
+		from, to                   string
+		fromInclusive, toInclusive bool
+		commits                    []string
+	}{
+		{"", "", true, true, seq},
+		{"bbbb", "cccc3333", true, true, seq[1:3]},
+		{"bbbb", "", false, true, seq[2:]},
+		{"", "cccc", true, false, seq[:2]},
+	}
+	for _, test := range tests {
+		commits, err := RangeCommits(seq, test.from, test.to, test.fromInclusive, test.toInclusive)
+		if err != nil {
+			t.Errorf("RangeCommits(%q, %q): %v", test.from, test.to, err)
+			continue
+		}
+		if !reflect.DeepEqual(commits, test.commits) {
+			t.Errorf("RangeCommits(%q, %q) = %v, expected %v", test.from, test.to, commits, test.commits)
+		}
+	}
+
+	// unknown commits, an empty range, abbreviations shorter than data.MinAbbrevLength and commits longer than the SHAs
+	for _, bounds := range [][2]string{{"eeee", ""}, {"", "ffff"}, {"cccc", "bbbb"}, {"bbb", ""}, {"", "cccc3333ff"}} {
+		if _, err := RangeCommits(seq, bounds[0], bounds[1], true, true); err == nil {
+			t.Errorf("RangeCommits(%q, %q): expected an error", bounds[0], bounds[1])
+		}
+	}
+}
+
+func TestRange(t *testing.T) {
+	tf, err := Range(seq, "bbbb", "cccc", true, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if c := commitsAfter(t, tf); !reflect.DeepEqual(c, seq[1:3]) {
+		t.Errorf("Commits = %v", c)
+	}
+	if _, err := Range(seq, "eeee", "", true, true); err == nil {
+		t.Errorf("Expected an error for a commit not in the sequence")
+	}
+}
+
+func TestLast(t *testing.T) {
+	tf, err := Last(seq, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if c := commitsAfter(t, tf); !reflect.DeepEqual(c, seq[2:]) {
+		t.Errorf("Commits = %v", c)
+	}
+
+	tf, err = Last(seq, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if c := commitsAfter(t, tf); !reflect.DeepEqual(c, seq) {
+		t.Errorf("Commits of more versions than available = %v", c)
+	}
+
+	if _, err := Last(seq, 0); err == nil {
+		t.Errorf("Expected an error for 0 versions")
+	}
+}
+
+func TestExclude(t *testing.T) {
+	if c := commitsAfter(t, Exclude(seq, []string{"bbbb", "unknown"})); !reflect.DeepEqual(c, []string{seq[0], seq[2], seq[3]}) {
+		t.Errorf("Commits = %v", c)
+	}
+	if c := commitsAfter(t, Exclude(seq, seq)); c != nil {
+		t.Errorf("Test without versions not filtered: %v", c)
+	}
+}
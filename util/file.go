@@ -0,0 +1,141 @@
+package util
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/klauspost/compress/zstd"
+)
+
+const (
+	// Stdin is the path of the standard input
+	Stdin  = "-"
+	gzExt  = ".gz"
+	zstExt = ".zst"
+)
+
+// magic bytes of compressed streams
+var (
+	gzMagic  = []byte{0x1f, 0x8b}
+	zstMagic = []byte{0x28, 0xb5, 0x2f, 0xfd}
+)
+
+// CompressionExt splits a path into the path without compression extension and the compression extension
+func CompressionExt(p string) (string, string) {
+	for _, ext := range []string{gzExt, zstExt} {
+		if strings.HasSuffix(p, ext) {
+			return strings.TrimSuffix(p, ext), ext
+		}
+	}
+	return p, ""
+}
+
+// Open opens a file for reading, which is decompressed if it ends in .gz or .zst or otherwise starts with the magic
+// bytes of gzip or zstd (e.g. a compressed stream piped to the standard input). The path '-' is the standard input.
+func Open(p string) (io.ReadCloser, error) {
+	var f *os.File
+	if p == Stdin {
+		f = os.Stdin
+	} else {
+		var err error
+		f, err = os.Open(p)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var in io.Reader = f
+	_, ext := CompressionExt(p)
+	if ext == "" {
+		br := bufio.NewReader(f)
+		ext = compressionOf(br)
+		in = br
+	}
+	switch ext {
+	case gzExt:
+		r, err := gzip.NewReader(in)
+		if err != nil {
+			f.Close()
+			return nil, err
+		}
+		return &readCloser{Reader: r, closers: []io.Closer{r, f}}, nil
+	case zstExt:
+		r, err := zstd.NewReader(in)
+		if err != nil {
+			f.Close()
+			return nil, err
+		}
+		return &readCloser{Reader: r, closers: []io.Closer{r.IOReadCloser(), f}}, nil
+	}
+	// the peeked bytes are buffered
+	return &readCloser{Reader: in, closers: []io.Closer{f}}, nil
+}
+
+// compressionOf returns the compression extension of the stream r by its magic bytes, or "" if it is not compressed
+func compressionOf(r *bufio.Reader) string {
+	// errors (e.g. shorter streams) are returned by the first read
+	b, _ := r.Peek(len(zstMagic))
+	switch {
+	case bytes.HasPrefix(b, gzMagic):
+		return gzExt
+	case bytes.HasPrefix(b, zstMagic):
+		return zstExt
+	}
+	return ""
+}
+
+// Create creates a file for writing, which is compressed if it ends in .gz or .zst
+func Create(p string) (io.WriteCloser, error) {
+	f, err := os.Create(p)
+	if err != nil {
+		return nil, err
+	}
+
+	_, ext := CompressionExt(p)
+	switch ext {
+	case gzExt:
+		w := gzip.NewWriter(f)
+		return &writeCloser{Writer: w, closers: []io.Closer{w, f}}, nil
+	case zstExt:
+		w, err := zstd.NewWriter(f)
+		if err != nil {
+			f.Close()
+			return nil, err
+		}
+		return &writeCloser{Writer: w, closers: []io.Closer{w, f}}, nil
+	}
+	return f, nil
+}
+
+type readCloser struct {
+	io.Reader
+	closers []io.Closer
+}
+
+func (r *readCloser) Close() error {
+	return closeAll(r.closers)
+}
+
+type writeCloser struct {
+	io.Writer
+	closers []io.Closer
+}
+
+func (w *writeCloser) Close() error {
+	return closeAll(w.closers)
+}
+
+// closeAll closes all closers in order and returns the first error
+func closeAll(cs []io.Closer) error {
+	var ret error
+	for _, c := range cs {
+		if err := c.Close(); err != nil && ret == nil {
+			ret = err
+		}
+	}
+	return ret
+}
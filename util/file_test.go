@@ -0,0 +1,135 @@
+package util
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestCompressionExt(t *testing.T) {
+	tests := []struct {
+		p    string
+		base string
+		ext  string
+	}{
+		{"results.csv", "results.csv", ""},
+		{"results.csv.gz", "results.csv", gzExt},
+		{"results.csv.zst", "results.csv", zstExt},
+		{"results.gz.csv", "results.gz.csv", ""},
+	}
+
+	for _, test := range tests {
+		base, ext := CompressionExt(test.p)
+		if base != test.base || ext != test.ext {
+			t.Errorf("CompressionExt(%q) = (%q, %q), expected (%q, %q)", test.p, base, ext, test.base, test.ext)
+		}
+	}
+}
+
+func TestCreateOpen(t *testing.T) {
+	dir, err := ioutil.TempDir("", "gopper")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	content := "project;version;sha;config;test;value\n"
+	for _, name := range []string{"plain.csv", "compressed.csv.gz", "compressed.csv.zst"} {
+		p := filepath.Join(dir, name)
+		w, err := Create(p)
+		if err != nil {
+			t.Fatalf("Create(%s): %v", name, err)
+		}
+		if _, err := w.Write([]byte(content)); err != nil {
+			t.Fatalf("Write(%s): %v", name, err)
+		}
+		if err := w.Close(); err != nil {
+			t.Fatalf("Close(%s): %v", name, err)
+		}
+
+		if _, ext := CompressionExt(name); ext != "" {
+			raw, err := ioutil.ReadFile(p)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(raw) == content {
+				t.Errorf("%s was not compressed", name)
+			}
+		}
+
+		r, err := Open(p)
+		if err != nil {
+			t.Fatalf("Open(%s): %v", name, err)
+		}
+		got, err := ioutil.ReadAll(r)
+		r.Close()
+		if err != nil {
+			t.Fatalf("ReadAll(%s): %v", name, err)
+		}
+		if string(got) != content {
+			t.Errorf("%s: read %q, expected %q", name, got, content)
+		}
+	}
+}
+
+func TestOpenInvalid(t *testing.T) {
+	dir, err := ioutil.TempDir("", "gopper")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	p := filepath.Join(dir, "invalid.csv.gz")
+	if err := ioutil.WriteFile(p, []byte("not gzip"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if r, err := Open(p); err == nil {
+		r.Close()
+		t.Errorf("Open(%s) succeeded on invalid gzip data", p)
+	}
+}
+
+func TestOpenDetectsCompression(t *testing.T) {
+	dir, err := ioutil.TempDir("", "gopper")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	content := "project;version;sha;config;test;value\n"
+	for _, name := range []string{"compressed.csv.gz", "compressed.csv.zst"} {
+		w, err := Create(filepath.Join(dir, name))
+		if err != nil {
+			t.Fatal(err)
+		}
+		w.Write([]byte(content))
+		w.Close()
+		// without extension, e.g. piped to the standard input
+		raw := filepath.Join(dir, name) + ".raw"
+		if err := os.Rename(filepath.Join(dir, name), raw); err != nil {
+			t.Fatal(err)
+		}
+
+		f, err := os.Open(raw)
+		if err != nil {
+			t.Fatal(err)
+		}
+		stdin := os.Stdin
+		os.Stdin = f
+		r, err := Open(Stdin)
+		if err != nil {
+			os.Stdin = stdin
+			t.Fatalf("Open(%s): %v", name, err)
+		}
+		got, err := ioutil.ReadAll(r)
+		r.Close()
+		os.Stdin = stdin
+		if err != nil {
+			t.Fatalf("ReadAll(%s): %v", name, err)
+		}
+		if string(got) != content {
+			t.Errorf("%s: read %q, expected %q", name, got, content)
+		}
+	}
+}
@@ -18,18 +18,26 @@ func AnalysisFunc(sps input.SubPrograms, in input.Config) bool {
 		return false
 	}
 
-	valid := false
-	for _, f := range input.AnalyseFuncs {
-		if f == funcName {
-			valid = true
-			break
-		}
+	if !analysisFuncName(funcName) {
+		return false
 	}
 
-	if !valid {
-		fmt.Printf("Analysis function '%s' invalid. Must be one of %v\n", funcName, input.AnalyseFuncs)
-		return false
+	// validate Analyse of projects
+	for _, p := range in.Projects {
+		if p.Analyse.Name != "" && !analysisFuncName(p.Analyse.Name) {
+			return false
+		}
 	}
 
 	return true
 }
+
+func analysisFuncName(funcName string) bool {
+	for _, f := range input.AnalyseFuncs {
+		if f == funcName {
+			return true
+		}
+	}
+	fmt.Printf("Analysis function '%s' invalid. Must be one of %v\n", funcName, input.AnalyseFuncs)
+	return false
+}
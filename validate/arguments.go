@@ -42,6 +42,9 @@ func Arguments(sps input.SubPrograms, in input.Config) {
 	invalid = invalid || !Transformators(sps, in)
 	invalid = invalid || !Plot(sps, in)
 	invalid = invalid || !AnalysisFunc(sps, in)
+	invalid = invalid || !Order(in)
+	invalid = invalid || !Merge(in)
+	invalid = invalid || !Gaps(in)
 
 	if invalid {
 		fmt.Println()
@@ -0,0 +1,28 @@
+package validate
+
+import (
+	"fmt"
+
+	"github.com/sealuzh/gopper/data/input"
+)
+
+func Gaps(in input.Config) bool {
+	name := in.Gaps.Name
+	if name == "" {
+		return true
+	}
+
+	switch name {
+	case input.GapsSkip, input.GapsBreak:
+		return true
+	case input.GapsMax:
+		n, err := input.IntParam(in.Gaps, 0)
+		if err != nil || n < 0 {
+			fmt.Printf("Gap policy '%s' requires the maximum number of missing versions (>= 0)\n", name)
+			return false
+		}
+		return true
+	}
+	fmt.Printf("Gap policy '%s' invalid. Must be one of %v\n", name, input.GapFuncs)
+	return false
+}
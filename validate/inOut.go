@@ -4,6 +4,8 @@ import (
 	"fmt"
 
 	"github.com/sealuzh/gopper/data/input"
+	"github.com/sealuzh/gopper/load"
+	"github.com/sealuzh/gopper/store"
 )
 
 func InOut(sps input.SubPrograms, in input.Config) bool {
@@ -29,5 +31,54 @@ func InOut(sps input.SubPrograms, in input.Config) bool {
 		}
 	}
 
+	return valid && inFormats(in) && filterReport(in)
+}
+
+func filterReport(in input.Config) bool {
+	if in.Out.FilterReport == "" {
+		return true
+	}
+	for _, f := range input.ReportFormats {
+		if in.Out.FilterReport == f {
+			return true
+		}
+	}
+	fmt.Printf("Invalid filter report format '%s'. Must be one of %v.\n", in.Out.FilterReport, input.ReportFormats)
+	return false
+}
+
+func inFormats(in input.Config) bool {
+	valid := true
+	for _, i := range in.In {
+		if i.Path == "" {
+			fmt.Printf("Input without path\n")
+			valid = false
+			continue
+		}
+		if i.Format == input.InSQLite && !store.IsSQLite(i.Path) {
+			fmt.Printf("Input '%s' is not a SQLite database. Must end in one of %v.\n", i.Path, store.Extensions)
+			valid = false
+			continue
+		}
+		if i.Format == "" || i.Format == input.InHopper {
+			if _, err := load.Dialect(i); err != nil {
+				fmt.Printf("Invalid CSV dialect for '%s': %v\n", i.Path, err)
+				valid = false
+			}
+			continue
+		}
+
+		var contains bool
+		for _, f := range input.InFormats {
+			if i.Format == f {
+				contains = true
+				break
+			}
+		}
+		if !contains {
+			fmt.Printf("Invalid input format '%s' for '%s'. Must be one of %v.\n", i.Format, i.Path, input.InFormats)
+			valid = false
+		}
+	}
 	return valid
 }
@@ -0,0 +1,22 @@
+package validate
+
+import (
+	"fmt"
+
+	"github.com/sealuzh/gopper/data/input"
+)
+
+func Merge(in input.Config) bool {
+	name := in.Merge.Name
+	if name == "" {
+		return true
+	}
+
+	for _, f := range input.MergeFuncs {
+		if f == name {
+			return true
+		}
+	}
+	fmt.Printf("Merge strategy '%s' invalid. Must be one of %v\n", name, input.MergeFuncs)
+	return false
+}
@@ -0,0 +1,40 @@
+package validate
+
+import (
+	"fmt"
+
+	"github.com/sealuzh/gopper/data/input"
+)
+
+func Order(in input.Config) bool {
+	name := in.Order.Name
+	if name == "" {
+		return true
+	}
+
+	valid := false
+	for _, f := range input.OrderFuncs {
+		if f == name {
+			valid = true
+			break
+		}
+	}
+	if !valid {
+		fmt.Printf("Order function '%s' invalid. Must be one of %v\n", name, input.OrderFuncs)
+		return false
+	}
+
+	switch name {
+	case input.OrderList:
+		if _, err := input.StringParam(in.Order, 0); err != nil {
+			fmt.Printf("Order function '%s' requires the path of a commit list: %v\n", name, err)
+			return false
+		}
+	case input.OrderGit:
+		if in.Git.Path == "" {
+			fmt.Printf("Order function '%s' requires a git repository (\"Git\")\n", name)
+			return false
+		}
+	}
+	return true
+}
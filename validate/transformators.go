@@ -4,6 +4,8 @@ import (
 	"fmt"
 
 	"github.com/sealuzh/gopper/data/input"
+	"github.com/sealuzh/gopper/transform/aggregate"
+	"github.com/sealuzh/gopper/transform/filter"
 )
 
 func Transformators(sps input.SubPrograms, in input.Config) bool {
@@ -16,24 +18,217 @@ func Transformators(sps input.SubPrograms, in input.Config) bool {
 
 	// validate Transform
 	if len(in.Transform) != 0 {
-		for _, t := range in.Transform {
-			var contains bool
-			for _, tf := range input.TransFuncs {
-				if t.Name == tf {
-					contains = true
-					break
-				}
-			}
+		valid = transFuncs(in.Transform)
+	} else {
+		valid = false
+	}
+
+	// validate Transform of projects
+	for _, p := range in.Projects {
+		valid = valid && transFuncs(p.Transform)
+	}
+
+	return valid
+}
 
-			if !contains {
-				fmt.Printf("Invalid transformer function '%s'. Must be one of %v.\n", t, input.TransFuncs)
-				valid = false
+func transFuncs(tfs []input.Func) bool {
+	for _, t := range tfs {
+		var contains bool
+		for _, tf := range input.TransFuncs {
+			if t.Name == tf {
+				contains = true
 				break
 			}
 		}
-	} else {
-		valid = false
+
+		if !contains {
+			fmt.Printf("Invalid transformer function '%s'. Must be one of %v.\n", t, input.TransFuncs)
+			return false
+		}
+
+		isCombinator := t.Name == input.And || t.Name == input.Or || t.Name == input.Not
+		if isCombinator && len(t.Funcs) == 0 {
+			fmt.Printf("Transformer function '%s' requires nested functions (Funcs)\n", t.Name)
+			return false
+		}
+		if !isCombinator && len(t.Funcs) != 0 {
+			fmt.Printf("Transformer function '%s' does not support nested functions (Funcs)\n", t.Name)
+			return false
+		}
+
+		switch t.Name {
+		case input.FilterMinMean, input.FilterMinMedian:
+			if _, err := input.Float64Param(t, 0); err != nil || len(t.Params) != 1 {
+				fmt.Printf("Transformer function '%s' requires a minimum value\n", t.Name)
+				return false
+			}
+		case input.FilterMinVersions:
+			if n, ok := intParam(t, 0); !ok || n < 1 || len(t.Params) != 1 {
+				fmt.Printf("Transformer function '%s' requires a number of versions of at least 1\n", t.Name)
+				return false
+			}
+		case input.FilterBaseName:
+			ns, err := input.StringParams(t, 0)
+			if err != nil {
+				fmt.Printf("Transformer function '%s' requires base names: %v\n", t.Name, err)
+				return false
+			}
+			for _, n := range ns {
+				if n == "" {
+					fmt.Printf("Transformer function '%s': empty base name\n", t.Name)
+					return false
+				}
+			}
+		case input.FilterParameter:
+			if k, err := input.StringParam(t, 0); err != nil || k == "" {
+				fmt.Printf("Transformer function '%s' requires a parameter name\n", t.Name)
+				return false
+			}
+			if _, err := input.StringParams(t, 1); err != nil {
+				fmt.Printf("Transformer function '%s' requires parameter values: %v\n", t.Name, err)
+				return false
+			}
+		case input.And, input.Or, input.Not:
+			if t.Name == input.Not && len(t.Funcs) != 1 {
+				fmt.Printf("Transformer function '%s' requires exactly one nested function, combine several with '%s'\n", t.Name, input.And)
+				return false
+			}
+			for _, nf := range t.Funcs {
+				if !isFilter(nf.Name) {
+					fmt.Printf("Transformer function '%s' does not support nested function '%s'. Must be one of %v.\n", t.Name, nf.Name, input.FilterFuncs)
+					return false
+				}
+			}
+			if !transFuncs(t.Funcs) {
+				return false
+			}
+		case input.FilterInclude, input.FilterExclude, input.FilterIncludeProj, input.FilterExcludeProj:
+			ps, err := input.StringParams(t, 0)
+			if err != nil {
+				fmt.Printf("Transformer function '%s' requires patterns: %v\n", t.Name, err)
+				return false
+			}
+			for _, p := range ps {
+				if _, err := filter.Pattern(p); err != nil {
+					fmt.Printf("Transformer function '%s': %v\n", t.Name, err)
+					return false
+				}
+			}
+		case input.Aggregate:
+			s, err := input.StringParam(t, 0)
+			if err != nil {
+				fmt.Printf("Transformer function '%s' requires a statistic: %v\n", t.Name, err)
+				return false
+			}
+			valid := false
+			for _, as := range aggregate.Statistics {
+				valid = valid || s == as
+			}
+			if !valid {
+				fmt.Printf("Transformer function '%s': invalid statistic '%s'. Must be one of %v\n", t.Name, s, aggregate.Statistics)
+				return false
+			}
+			pos := 1
+			if s == aggregate.Percentile {
+				if p, err := input.Float64Param(t, pos); err != nil || p <= 0 || p > 100 {
+					fmt.Printf("Transformer function '%s' requires a percentile in (0, 100]\n", t.Name)
+					return false
+				}
+				pos++
+			}
+			if len(t.Params) > pos+1 {
+				fmt.Printf("Transformer function '%s' supports only the statistic, its percentile and whether to keep the dispersion\n", t.Name)
+				return false
+			}
+			if _, err := input.BoolParam(t, pos); len(t.Params) == pos+1 && err != nil {
+				fmt.Printf("Transformer function '%s': %v\n", t.Name, err)
+				return false
+			}
+		case input.SliceRange:
+			if len(t.Params) < 2 || len(t.Params) > 4 {
+				fmt.Printf("Transformer function '%s' requires the commits from and to and optionally whether they are inclusive\n", t.Name)
+				return false
+			}
+			for i := range t.Params {
+				var err error
+				if i < 2 {
+					_, err = input.StringParam(t, i)
+				} else {
+					_, err = input.BoolParam(t, i)
+				}
+				if err != nil {
+					fmt.Printf("Transformer function '%s': %v\n", t.Name, err)
+					return false
+				}
+			}
+		case input.SliceLast:
+			if n, ok := intParam(t, 0); !ok || n < 1 || len(t.Params) != 1 {
+				fmt.Printf("Transformer function '%s' requires a number of versions of at least 1\n", t.Name)
+				return false
+			}
+		case input.SliceExclude:
+			cs, err := input.StringParams(t, 0)
+			if err != nil {
+				fmt.Printf("Transformer function '%s' requires commits: %v\n", t.Name, err)
+				return false
+			}
+			for _, c := range cs {
+				if c == "" {
+					fmt.Printf("Transformer function '%s': empty commit\n", t.Name)
+					return false
+				}
+			}
+		case input.SampleMin:
+			if n, ok := intParam(t, 0); !ok || n < 1 || len(t.Params) != 1 {
+				fmt.Printf("Transformer function '%s' requires a number of executions of at least 1\n", t.Name)
+				return false
+			}
+		case input.SampleMax:
+			if m, ok := intParam(t, 0); !ok || m < 1 || len(t.Params) > 2 {
+				fmt.Printf("Transformer function '%s' requires a number of executions of at least 1 and optionally a seed\n", t.Name)
+				return false
+			}
+			if _, ok := intParam(t, 1); len(t.Params) == 2 && !ok {
+				fmt.Printf("Transformer function '%s' requires an integer seed\n", t.Name)
+				return false
+			}
+		case input.CpsMinCategory:
+			// the highest category is 90 - 100 %
+			if p, ok := intParam(t, 0); !ok || p < 0 || p > 90 || len(t.Params) != 1 {
+				fmt.Printf("Transformer function '%s' requires a percentage between 0 and 90\n", t.Name)
+				return false
+			}
+		case input.CpsRange:
+			if len(t.Params) != 2 {
+				fmt.Printf("Transformer function '%s' requires the commits from and to\n", t.Name)
+				return false
+			}
+			if _, err := input.StringParams(t, 0); err != nil {
+				fmt.Printf("Transformer function '%s': %v\n", t.Name, err)
+				return false
+			}
+		}
 	}
+	return true
+}
 
-	return valid
+// intParam returns the parameter of f at pos, if it is an integer
+func intParam(f input.Func, pos int) (int, bool) {
+	if pos >= len(f.Params) {
+		return 0, false
+	}
+	v, ok := f.Params[pos].(float64)
+	if !ok || v != float64(int(v)) {
+		return 0, false
+	}
+	return int(v), true
+}
+
+func isFilter(name string) bool {
+	for _, f := range input.FilterFuncs {
+		if name == f {
+			return true
+		}
+	}
+	return false
 }
@@ -0,0 +1,78 @@
+package validate
+
+import (
+	"testing"
+
+	"github.com/sealuzh/gopper/data/input"
+)
+
+func TestTransFuncs(t *testing.T) {
+	include := input.Func{Name: input.FilterInclude, Params: []interface{}{"*Bench"}}
+	tests := []struct {
+		f     input.Func
+		valid bool
+	}{
+		{input.Func{Name: input.FilterMinMean, Params: []interface{}{0.01}}, true},
+		{input.Func{Name: input.FilterMinMean, Params: []interface{}{"0.01"}}, false},
+		{input.Func{Name: input.FilterMinMedian}, false},
+		{input.Func{Name: input.FilterMinVersions, Params: []interface{}{2.0}}, true},
+		{input.Func{Name: input.FilterMinVersions, Params: []interface{}{0.0}}, false},
+		{input.Func{Name: input.FilterMinVersions, Params: []interface{}{1.5}}, false},
+		{input.Func{Name: input.FilterBaseName, Params: []interface{}{"Bench.sort", "Bench.map"}}, true},
+		{input.Func{Name: input.FilterBaseName}, false},
+		{input.Func{Name: input.FilterBaseName, Params: []interface{}{1.0}}, false},
+		{input.Func{Name: input.FilterParameter, Params: []interface{}{"size", "10", "100"}}, true},
+		{input.Func{Name: input.FilterParameter, Params: []interface{}{"size"}}, false},
+		{input.Func{Name: input.FilterParameter, Params: []interface{}{"", "10"}}, false},
+		{input.Func{Name: input.FilterParameter, Params: []interface{}{"size", 10.0}}, false},
+		{input.Func{Name: input.FilterExclude, Params: []interface{}{"re:Bench("}}, false},
+		{input.Func{Name: input.FilterInclude}, false},
+		{input.Func{Name: input.Aggregate, Params: []interface{}{"mean"}}, true},
+		{input.Func{Name: input.Aggregate, Params: []interface{}{"mean", true}}, true},
+		{input.Func{Name: input.Aggregate, Params: []interface{}{"percentile", 100.0, true}}, true},
+		{input.Func{Name: input.Aggregate, Params: []interface{}{"percentile", 0.0}}, false},
+		{input.Func{Name: input.Aggregate, Params: []interface{}{"percentile", 101.0}}, false},
+		{input.Func{Name: input.Aggregate, Params: []interface{}{"percentile"}}, false},
+		{input.Func{Name: input.Aggregate, Params: []interface{}{"mean", "yes"}}, false},
+		{input.Func{Name: input.Aggregate, Params: []interface{}{"mean", true, true}}, false},
+		{input.Func{Name: input.Aggregate, Params: []interface{}{"mode"}}, false},
+		{input.Func{Name: input.SliceRange, Params: []interface{}{"a1b2", ""}}, true},
+		{input.Func{Name: input.SliceRange, Params: []interface{}{"a1b2", "c3d4", true, false}}, true},
+		{input.Func{Name: input.SliceRange, Params: []interface{}{"a1b2"}}, false},
+		{input.Func{Name: input.SliceRange, Params: []interface{}{"a1b2", 1.0}}, false},
+		{input.Func{Name: input.SliceRange, Params: []interface{}{"a1b2", "c3d4", "yes"}}, false},
+		{input.Func{Name: input.SliceLast, Params: []interface{}{3.0}}, true},
+		{input.Func{Name: input.SliceLast, Params: []interface{}{0.0}}, false},
+		{input.Func{Name: input.SliceLast, Params: []interface{}{2.5}}, false},
+		{input.Func{Name: input.SliceLast}, false},
+		{input.Func{Name: input.SliceExclude, Params: []interface{}{"a1b2", "c3d4"}}, true},
+		{input.Func{Name: input.SliceExclude}, false},
+		{input.Func{Name: input.SliceExclude, Params: []interface{}{""}}, false},
+		{input.Func{Name: input.SampleMin, Params: []interface{}{5.0}}, true},
+		{input.Func{Name: input.SampleMin, Params: []interface{}{0.0}}, false},
+		{input.Func{Name: input.SampleMin, Params: []interface{}{-2.0}}, false},
+		{input.Func{Name: input.SampleMax, Params: []interface{}{5.0}}, true},
+		{input.Func{Name: input.SampleMax, Params: []interface{}{5.0, 42.0}}, true},
+		{input.Func{Name: input.SampleMax, Params: []interface{}{0.0}}, false},
+		{input.Func{Name: input.SampleMax, Params: []interface{}{5.0, 4.2}}, false},
+		{input.Func{Name: input.SampleMax, Params: []interface{}{5.0, "42"}}, false},
+		{input.Func{Name: input.CpsMinCategory, Params: []interface{}{20.0}}, true},
+		{input.Func{Name: input.CpsMinCategory, Params: []interface{}{90.0}}, true},
+		{input.Func{Name: input.CpsMinCategory, Params: []interface{}{100.0}}, false},
+		{input.Func{Name: input.CpsMinCategory, Params: []interface{}{-10.0}}, false},
+		{input.Func{Name: input.CpsRange, Params: []interface{}{"a1b2", ""}}, true},
+		{input.Func{Name: input.CpsRange, Params: []interface{}{"a1b2"}}, false},
+		{input.Func{Name: input.CpsRange, Params: []interface{}{"a1b2", true}}, false},
+		{input.Func{Name: input.Not, Funcs: []input.Func{include}}, true},
+		{input.Func{Name: input.Not, Funcs: []input.Func{include, include}}, false},
+		{input.Func{Name: input.Not}, false},
+		{input.Func{Name: input.And, Funcs: []input.Func{include, {Name: input.Or, Funcs: []input.Func{include, {Name: input.CpsAny}}}}}, true},
+		{input.Func{Name: input.Or, Funcs: []input.Func{include, {Name: input.NormaliseLog}}}, false},
+		{input.Func{Name: input.And, Funcs: []input.Func{{Name: input.SliceLast, Params: []interface{}{2.0}}}}, false},
+	}
+	for _, test := range tests {
+		if valid := transFuncs([]input.Func{test.f}); valid != test.valid {
+			t.Errorf("transFuncs(%v) = %t, expected %t", test.f, valid, test.valid)
+		}
+	}
+}